@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata"
 
 	"github.com/LehaAlexey/Users/config"
 	"github.com/LehaAlexey/Users/internal/bootstrap"
@@ -33,4 +34,3 @@ func main() {
 		panic(err)
 	}
 }
-
@@ -8,12 +8,16 @@ import (
 )
 
 type Config struct {
-	Database DatabaseConfig `yaml:"database"`
-	Kafka    KafkaConfig    `yaml:"kafka"`
-	HTTP     HTTPConfig     `yaml:"http"`
-	GRPC     GRPCConfig     `yaml:"grpc"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Kafka     KafkaConfig     `yaml:"kafka"`
+	HTTP      HTTPConfig      `yaml:"http"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
 	Scheduler SchedulerConfig `yaml:"scheduler"`
-	Swagger  SwaggerConfig  `yaml:"swagger"`
+	Purge     PurgeConfig     `yaml:"purge"`
+	Outbox    OutboxConfig    `yaml:"outbox"`
+	Trigger   TriggerConfig   `yaml:"trigger"`
+	Publisher PublisherConfig `yaml:"publisher"`
+	Swagger   SwaggerConfig   `yaml:"swagger"`
 }
 
 type DatabaseConfig struct {
@@ -25,10 +29,29 @@ type DatabaseConfig struct {
 	SSLMode  string `yaml:"ssl_mode"`
 }
 
+// KafkaConfig describes the cluster and producer settings. Brokers takes
+// precedence over Host and Port.
 type KafkaConfig struct {
-	Host               string `yaml:"host"`
-	Port               int    `yaml:"port"`
-	ParseRequestedTopic string `yaml:"parse_requested_topic_name"`
+	Host                    string          `yaml:"host"`
+	Port                    int             `yaml:"port"`
+	Brokers                 []string        `yaml:"brokers"`
+	TLS                     KafkaTLSConfig  `yaml:"tls"`
+	SASL                    KafkaSASLConfig `yaml:"sasl"`
+	Acks                    string          `yaml:"acks"`
+	Compression             string          `yaml:"compression"`
+	BatchSize               int             `yaml:"batch_size"`
+	BatchBytes              int64           `yaml:"batch_bytes"`
+	BatchTimeoutMillis      int             `yaml:"batch_timeout_millis"`
+	MaxAttempts             int             `yaml:"max_attempts"`
+	RetryBackoffMinMillis   int             `yaml:"retry_backoff_min_millis"`
+	RetryBackoffMaxMillis   int             `yaml:"retry_backoff_max_millis"`
+	ParseRequestedTopic     string          `yaml:"parse_requested_topic_name"`
+	ParseRequestedHighTopic string          `yaml:"parse_requested_high_topic_name"`
+	ParseCompletedTopic     string          `yaml:"parse_completed_topic_name"`
+	ParseFailedTopic        string          `yaml:"parse_failed_topic_name"`
+	URLDisabledTopic        string          `yaml:"url_disabled_topic_name"`
+	UserEventsTopic         string          `yaml:"user_events_topic_name"`
+	ConsumerGroup           string          `yaml:"consumer_group"`
 }
 
 type HTTPConfig struct {
@@ -40,17 +63,223 @@ type GRPCConfig struct {
 }
 
 type SchedulerConfig struct {
-	TickSeconds            int `yaml:"tick_seconds"`
-	DefaultIntervalSeconds int `yaml:"default_interval_seconds"`
-	MaxBatch               int `yaml:"max_batch"`
+	TickSeconds            int             `yaml:"tick_seconds"`
+	DefaultIntervalSeconds int             `yaml:"default_interval_seconds"`
+	MaxBatch               int             `yaml:"max_batch"`
+	ClaimLeaseSeconds      int             `yaml:"claim_lease_seconds"`
+	Mode                   string          `yaml:"mode"`
+	LeaderLockKey          int64           `yaml:"leader_lock_key"`
+	LeaderRetrySeconds     int             `yaml:"leader_retry_seconds"`
+	BackoffMaxSeconds      int             `yaml:"backoff_max_seconds"`
+	BackoffJitter          float64         `yaml:"backoff_jitter"`
+	DisableAfterFailures   int             `yaml:"disable_after_failures"`
+	JitterFraction         *float64        `yaml:"jitter_fraction"`
+	CronJitterSeconds      int             `yaml:"cron_jitter_seconds"`
+	InitialSpreadSeconds   int             `yaml:"initial_spread_seconds"`
+	CatchUp                string          `yaml:"catch_up"`
+	RateLimit              RateLimitConfig `yaml:"rate_limit"`
+	Fairness               FairnessConfig  `yaml:"fairness"`
+	HighPriorityThreshold  int             `yaml:"high_priority_threshold"`
+}
+
+// FairnessConfig sets per-user quotas for claiming due URLs. Users are
+// matched by users.plan; plans not listed here use the top-level values.
+type FairnessConfig struct {
+	MaxPerBatch int                   `yaml:"max_per_batch"`
+	Weight      int                   `yaml:"weight"`
+	Plans       map[string]PlanConfig `yaml:"plans"`
+}
+
+type PlanConfig struct {
+	MaxPerBatch int `yaml:"max_per_batch"`
+	Weight      int `yaml:"weight"`
+}
+
+type RateLimitConfig struct {
+	RatePerSecond float64                    `yaml:"rate_per_second"`
+	Burst         int                        `yaml:"burst"`
+	Domains       map[string]HostLimitConfig `yaml:"domains"`
+}
+
+type HostLimitConfig struct {
+	RatePerSecond float64 `yaml:"rate_per_second"`
+	Burst         int     `yaml:"burst"`
+}
+
+type PurgeConfig struct {
+	TickSeconds      int `yaml:"tick_seconds"`
+	RetentionSeconds int `yaml:"retention_seconds"`
+	MaxBatch         int `yaml:"max_batch"`
+}
+
+type OutboxConfig struct {
+	TickMillis       int `yaml:"tick_millis"`
+	BatchSize        int `yaml:"batch_size"`
+	LeaseSeconds     int `yaml:"lease_seconds"`
+	RetryBaseMillis  int `yaml:"retry_base_millis"`
+	RetryMaxSeconds  int `yaml:"retry_max_seconds"`
+	MaxAttempts      int `yaml:"max_attempts"`
+	RetentionSeconds int `yaml:"retention_seconds"`
+}
+
+// TriggerConfig limits on-demand parse requests per user.
+type TriggerConfig struct {
+	RatePerMinute float64 `yaml:"rate_per_minute"`
+	Burst         int     `yaml:"burst"`
+}
+
+type KafkaTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// KafkaSASLConfig enables SASL when Mechanism is set: plain, scram-sha-256
+// or scram-sha-512.
+type KafkaSASLConfig struct {
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// PublisherConfig selects where outbox events are published, the wire format
+// of ParseRequested (json or protobuf) and the CloudEvents envelope. The kafka
+// backend uses the kafka section; postgres uses the main database.
+type PublisherConfig struct {
+	Backend     string            `yaml:"backend"`
+	Format      string            `yaml:"format"`
+	CloudEvents CloudEventsConfig `yaml:"cloudevents"`
+	NATS        NATSConfig        `yaml:"nats"`
+	Webhook     WebhookConfig     `yaml:"webhook"`
+	File        FileConfig        `yaml:"file"`
+}
+
+// CloudEventsConfig wraps every event in a CloudEvents 1.0 envelope when Mode
+// is binary or structured. Source defaults to /users-service.
+type CloudEventsConfig struct {
+	Mode   string `yaml:"mode"`
+	Source string `yaml:"source"`
+}
+
+type NATSConfig struct {
+	URL    string `yaml:"url"`
+	Stream string `yaml:"stream"`
+}
+
+type WebhookConfig struct {
+	URL            string            `yaml:"url"`
+	TimeoutSeconds int               `yaml:"timeout_seconds"`
+	Headers        map[string]string `yaml:"headers"`
+}
+
+type FileConfig struct {
+	Path string `yaml:"path"`
 }
 
 type SwaggerConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	Path    string `yaml:"path"`
+	Enabled  bool   `yaml:"enabled"`
+	Path     string `yaml:"path"`
 	SpecPath string `yaml:"spec_path"`
 }
 
+func (c SchedulerConfig) Validate() error {
+	switch c.Mode {
+	case "", "claim", "leader":
+	default:
+		return fmt.Errorf("scheduler.mode must be one of claim, leader: got %q", c.Mode)
+	}
+	switch c.CatchUp {
+	case "", "skip", "all":
+	default:
+		return fmt.Errorf("scheduler.catch_up must be one of skip, all: got %q", c.CatchUp)
+	}
+	if c.JitterFraction != nil && (*c.JitterFraction < 0 || *c.JitterFraction > 1) {
+		return fmt.Errorf("scheduler.jitter_fraction must be within [0, 1], 0 to disable interval jitter: got %v", *c.JitterFraction)
+	}
+	return nil
+}
+
+// BrokerList returns Brokers, or Host:Port when no list is configured.
+func (c KafkaConfig) BrokerList() []string {
+	if len(c.Brokers) > 0 {
+		return c.Brokers
+	}
+	return []string{fmt.Sprintf("%s:%d", c.Host, c.Port)}
+}
+
+func (c KafkaConfig) Validate() error {
+	if len(c.Brokers) == 0 && (c.Host == "" || c.Port <= 0) {
+		return fmt.Errorf("kafka.brokers or kafka.host and kafka.port are required")
+	}
+	switch c.Acks {
+	case "", "none", "one", "all":
+	default:
+		return fmt.Errorf("kafka.acks must be one of none, one, all: got %q", c.Acks)
+	}
+	switch c.Compression {
+	case "", "none", "gzip", "snappy", "lz4", "zstd":
+	default:
+		return fmt.Errorf("kafka.compression must be one of none, gzip, snappy, lz4, zstd: got %q", c.Compression)
+	}
+	switch c.SASL.Mechanism {
+	case "":
+	case "plain", "scram-sha-256", "scram-sha-512":
+		if c.SASL.Username == "" {
+			return fmt.Errorf("kafka.sasl.username is required for %s", c.SASL.Mechanism)
+		}
+	default:
+		return fmt.Errorf("kafka.sasl.mechanism must be one of plain, scram-sha-256, scram-sha-512: got %q", c.SASL.Mechanism)
+	}
+	// TLS settings without tls.enabled would be dropped silently and the
+	// client would connect in plaintext.
+	if !c.TLS.Enabled && (c.TLS.CAFile != "" || c.TLS.CertFile != "" || c.TLS.KeyFile != "" || c.TLS.InsecureSkipVerify) {
+		return fmt.Errorf("kafka.tls.ca_file, cert_file, key_file and insecure_skip_verify require kafka.tls.enabled: true")
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return fmt.Errorf("kafka.tls.cert_file and kafka.tls.key_file must be set together")
+	}
+	if c.BatchSize < 0 || c.BatchBytes < 0 || c.BatchTimeoutMillis < 0 || c.MaxAttempts < 0 {
+		return fmt.Errorf("kafka batch and retry settings must not be negative")
+	}
+	if c.RetryBackoffMaxMillis > 0 && c.RetryBackoffMinMillis > c.RetryBackoffMaxMillis {
+		return fmt.Errorf("kafka.retry_backoff_min_millis must not exceed kafka.retry_backoff_max_millis")
+	}
+	return nil
+}
+
+func (c PublisherConfig) Validate() error {
+	switch c.Format {
+	case "", "json", "protobuf":
+	default:
+		return fmt.Errorf("publisher.format must be one of json, protobuf: got %q", c.Format)
+	}
+	switch c.CloudEvents.Mode {
+	case "", "binary", "structured":
+	default:
+		return fmt.Errorf("publisher.cloudevents.mode must be one of binary, structured: got %q", c.CloudEvents.Mode)
+	}
+	switch c.Backend {
+	case "", "kafka", "postgres", "stdout":
+	case "nats":
+		if c.NATS.URL == "" {
+			return fmt.Errorf("publisher.nats.url is required")
+		}
+	case "webhook":
+		if c.Webhook.URL == "" {
+			return fmt.Errorf("publisher.webhook.url is required")
+		}
+	case "file":
+		if c.File.Path == "" {
+			return fmt.Errorf("publisher.file.path is required")
+		}
+	default:
+		return fmt.Errorf("publisher.backend must be one of kafka, nats, postgres, webhook, stdout, file: got %q", c.Backend)
+	}
+	return nil
+}
+
 func LoadConfig(filename string) (*Config, error) {
 	bytes, err := os.ReadFile(filename)
 	if err != nil {
@@ -61,6 +290,17 @@ func LoadConfig(filename string) (*Config, error) {
 	if err := yaml.Unmarshal(bytes, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
+	if err := cfg.Scheduler.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if cfg.Publisher.Backend == "" || cfg.Publisher.Backend == "kafka" {
+		if err := cfg.Kafka.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+	if err := cfg.Publisher.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
 
 	return &cfg, nil
 }
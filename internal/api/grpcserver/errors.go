@@ -0,0 +1,61 @@
+package grpcserver
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/LehaAlexey/Users/internal/services/userservice"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func toStatus(err error) error {
+	var svcErr *userservice.Error
+	if !errors.As(err, &svcErr) {
+		slog.Error("grpcserver: unexpected error", "error", err.Error())
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(svcErr, userservice.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(svcErr, userservice.ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(svcErr, userservice.ErrConflict):
+		code = codes.FailedPrecondition
+	case errors.Is(svcErr, userservice.ErrInvalidArgument):
+		code = codes.InvalidArgument
+	case errors.Is(svcErr, userservice.ErrRateLimited):
+		code = codes.ResourceExhausted
+	default:
+		slog.Error("grpcserver: internal error", "error", err.Error())
+	}
+
+	st := status.New(code, svcErr.Message)
+	if svcErr.RetryAfter > 0 {
+		detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(svcErr.RetryAfter)})
+		if detailErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	if len(svcErr.Violations) == 0 {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range svcErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	detailed, detailErr := st.WithDetails(badRequest)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,21 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/LehaAlexey/Users/internal/correlation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func CorrelationInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var raw string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(correlation.MetadataRequestID); len(values) > 0 {
+			raw = values[0]
+		}
+	}
+	id := correlation.Normalize(raw)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(correlation.MetadataRequestID, id))
+	return handler(correlation.WithID(ctx, id), req)
+}
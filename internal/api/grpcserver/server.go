@@ -2,6 +2,8 @@ package grpcserver
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/LehaAlexey/Users/internal/models"
 	"github.com/LehaAlexey/Users/internal/pb/users"
@@ -11,8 +13,22 @@ import (
 type Service interface {
 	CreateUser(ctx context.Context, req userservice.CreateUserRequest) (*models.User, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
-	AddURL(ctx context.Context, userID string, rawURL string, intervalSeconds int) (*models.UserURL, error)
-	ListUserURLs(ctx context.Context, userID string, limit int) ([]models.UserURL, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	ListUsers(ctx context.Context, req userservice.ListUsersRequest) (*models.UserPage, error)
+	UpdateUser(ctx context.Context, userID string, req userservice.UpdateUserRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	RestoreUser(ctx context.Context, userID string) (*models.User, error)
+	AddURL(ctx context.Context, userID string, req userservice.AddURLRequest) (*models.UserURL, error)
+	ListUserURLs(ctx context.Context, userID string, req userservice.ListURLsRequest) (*models.URLPage, error)
+	UpdateURL(ctx context.Context, userID string, urlID string, req userservice.UpdateURLRequest) (*models.UserURL, error)
+	PauseURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error)
+	ResumeURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error)
+	DeleteURL(ctx context.Context, userID string, urlID string) error
+	TriggerParse(ctx context.Context, userID string, urlID string) (*models.ParseTrigger, error)
+	ListPublishFailures(ctx context.Context, afterID string, limit int) ([]models.PublishFailure, error)
+	ReplayPublishFailure(ctx context.Context, id string) error
+	DiscardPublishFailure(ctx context.Context, id string) error
+	RecordRunResult(ctx context.Context, result models.RunResult) error
 }
 
 type Server struct {
@@ -27,7 +43,7 @@ func New(service Service) *Server {
 func (s *Server) CreateUser(ctx context.Context, req *users.CreateUserRequest) (*users.CreateUserResponse, error) {
 	u, err := s.service.CreateUser(ctx, userservice.CreateUserRequest{Email: req.Email, Name: req.Name})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &users.CreateUserResponse{User: mapUser(u)}, nil
 }
@@ -35,32 +51,199 @@ func (s *Server) CreateUser(ctx context.Context, req *users.CreateUserRequest) (
 func (s *Server) GetUser(ctx context.Context, req *users.GetUserRequest) (*users.GetUserResponse, error) {
 	u, err := s.service.GetUser(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &users.GetUserResponse{User: mapUser(u)}, nil
 }
 
+func (s *Server) GetUserByEmail(ctx context.Context, req *users.GetUserByEmailRequest) (*users.GetUserByEmailResponse, error) {
+	u, err := s.service.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.GetUserByEmailResponse{User: mapUser(u)}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *users.ListUsersRequest) (*users.ListUsersResponse, error) {
+	listReq := userservice.ListUsersRequest{
+		Limit:  int(req.Limit),
+		Cursor: req.PageToken,
+		Query:  req.Query,
+	}
+	if req.CreatedFrom > 0 {
+		t := time.Unix(req.CreatedFrom, 0).UTC()
+		listReq.CreatedFrom = &t
+	}
+	if req.CreatedTo > 0 {
+		t := time.Unix(req.CreatedTo, 0).UTC()
+		listReq.CreatedTo = &t
+	}
+	page, err := s.service.ListUsers(ctx, listReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &users.ListUsersResponse{Users: make([]*users.UserSummary, 0, len(page.Users)), NextPageToken: page.NextCursor}
+	for i := range page.Users {
+		resp.Users = append(resp.Users, &users.UserSummary{
+			User:     mapUser(&page.Users[i].User),
+			UrlCount: int64(page.Users[i].URLCount),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateUser(ctx context.Context, req *users.UpdateUserRequest) (*users.UpdateUserResponse, error) {
+	u, err := s.service.UpdateUser(ctx, req.Id, userservice.UpdateUserRequest{Email: req.Email, Name: req.Name})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.UpdateUserResponse{User: mapUser(u)}, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, req *users.DeleteUserRequest) (*users.DeleteUserResponse, error) {
+	if err := s.service.DeleteUser(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.DeleteUserResponse{}, nil
+}
+
+func (s *Server) RestoreUser(ctx context.Context, req *users.RestoreUserRequest) (*users.RestoreUserResponse, error) {
+	u, err := s.service.RestoreUser(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.RestoreUserResponse{User: mapUser(u)}, nil
+}
+
 func (s *Server) AddUrl(ctx context.Context, req *users.AddUrlRequest) (*users.AddUrlResponse, error) {
-	u, err := s.service.AddURL(ctx, req.UserId, req.Url, int(req.PollingIntervalSeconds))
+	u, err := s.service.AddURL(ctx, req.UserId, userservice.AddURLRequest{
+		URL:                    req.Url,
+		PollingIntervalSeconds: int(req.PollingIntervalSeconds),
+		CronExpr:               req.CronExpr,
+		Timezone:               req.Timezone,
+		Priority:               int(req.Priority),
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &users.AddUrlResponse{Url: mapUserURL(u)}, nil
 }
 
 func (s *Server) ListUrls(ctx context.Context, req *users.ListUrlsRequest) (*users.ListUrlsResponse, error) {
-	items, err := s.service.ListUserURLs(ctx, req.UserId, int(req.Limit))
+	page, err := s.service.ListUserURLs(ctx, req.UserId, userservice.ListURLsRequest{
+		Limit:  int(req.Limit),
+		Cursor: req.PageToken,
+		Host:   req.Host,
+		Status: req.Status,
+		Search: req.Search,
+		Sort:   req.Sort,
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
-	resp := &users.ListUrlsResponse{Urls: make([]*users.UserURL, 0, len(items))}
-	for _, item := range items {
+	resp := &users.ListUrlsResponse{Urls: make([]*users.UserURL, 0, len(page.URLs)), NextPageToken: page.NextCursor}
+	for _, item := range page.URLs {
 		itemCopy := item
 		resp.Urls = append(resp.Urls, mapUserURL(&itemCopy))
 	}
 	return resp, nil
 }
 
+func (s *Server) UpdateUrl(ctx context.Context, req *users.UpdateUrlRequest) (*users.UpdateUrlResponse, error) {
+	update := userservice.UpdateURLRequest{PollingIntervalSeconds: int(req.PollingIntervalSeconds)}
+	if req.Priority != nil {
+		priority := int(*req.Priority)
+		update.Priority = &priority
+	}
+	u, err := s.service.UpdateURL(ctx, req.UserId, req.UrlId, update)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.UpdateUrlResponse{Url: mapUserURL(u)}, nil
+}
+
+func (s *Server) PauseUrl(ctx context.Context, req *users.PauseUrlRequest) (*users.PauseUrlResponse, error) {
+	u, err := s.service.PauseURL(ctx, req.UserId, req.UrlId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.PauseUrlResponse{Url: mapUserURL(u)}, nil
+}
+
+func (s *Server) ResumeUrl(ctx context.Context, req *users.ResumeUrlRequest) (*users.ResumeUrlResponse, error) {
+	u, err := s.service.ResumeURL(ctx, req.UserId, req.UrlId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.ResumeUrlResponse{Url: mapUserURL(u)}, nil
+}
+
+func (s *Server) DeleteUrl(ctx context.Context, req *users.DeleteUrlRequest) (*users.DeleteUrlResponse, error) {
+	if err := s.service.DeleteURL(ctx, req.UserId, req.UrlId); err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.DeleteUrlResponse{}, nil
+}
+
+func (s *Server) TriggerParse(ctx context.Context, req *users.TriggerParseRequest) (*users.TriggerParseResponse, error) {
+	trigger, err := s.service.TriggerParse(ctx, req.UserId, req.UrlId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.TriggerParseResponse{
+		UrlId:         trigger.URLID,
+		EventId:       trigger.EventID,
+		CorrelationId: trigger.CorrelationID,
+		RequestedAt:   trigger.RequestedAt.Unix(),
+	}, nil
+}
+
+func (s *Server) ReportParseResult(ctx context.Context, req *users.ReportParseResultRequest) (*users.ReportParseResultResponse, error) {
+	result := models.RunResult{
+		URLID:         req.UrlId,
+		EventID:       req.EventId,
+		CorrelationID: req.CorrelationId,
+		Status:        models.RunStatusSucceeded,
+		Error:         req.Error,
+	}
+	if !req.Success {
+		result.Status = models.RunStatusFailed
+	}
+	if req.FinishedAt > 0 {
+		result.FinishedAt = time.Unix(req.FinishedAt, 0).UTC()
+	}
+	if err := s.service.RecordRunResult(ctx, result); err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.ReportParseResultResponse{}, nil
+}
+
+func (s *Server) ListPublishFailures(ctx context.Context, req *users.ListPublishFailuresRequest) (*users.ListPublishFailuresResponse, error) {
+	items, err := s.service.ListPublishFailures(ctx, strconv.FormatInt(req.AfterId, 10), int(req.Limit))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := make([]*users.PublishFailure, 0, len(items))
+	for i := range items {
+		out = append(out, mapPublishFailure(&items[i]))
+	}
+	return &users.ListPublishFailuresResponse{Failures: out}, nil
+}
+
+func (s *Server) ReplayPublishFailure(ctx context.Context, req *users.ReplayPublishFailureRequest) (*users.ReplayPublishFailureResponse, error) {
+	if err := s.service.ReplayPublishFailure(ctx, strconv.FormatInt(req.Id, 10)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.ReplayPublishFailureResponse{}, nil
+}
+
+func (s *Server) DiscardPublishFailure(ctx context.Context, req *users.DiscardPublishFailureRequest) (*users.DiscardPublishFailureResponse, error) {
+	if err := s.service.DiscardPublishFailure(ctx, strconv.FormatInt(req.Id, 10)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &users.DiscardPublishFailureResponse{}, nil
+}
+
 func mapUser(u *models.User) *users.User {
 	if u == nil {
 		return nil
@@ -77,12 +260,46 @@ func mapUserURL(u *models.UserURL) *users.UserURL {
 	if u == nil {
 		return nil
 	}
+	var lastRunAt, disabledAt int64
+	if u.LastRunAt != nil {
+		lastRunAt = u.LastRunAt.Unix()
+	}
+	if u.DisabledAt != nil {
+		disabledAt = u.DisabledAt.Unix()
+	}
 	return &users.UserURL{
-		Id:            u.ID,
-		UserId:        u.UserID,
-		Url:           u.URL,
-		NormalizedUrl: u.NormalizedURL,
+		Id:                     u.ID,
+		UserId:                 u.UserID,
+		Url:                    u.URL,
+		NormalizedUrl:          u.NormalizedURL,
 		PollingIntervalSeconds: int32(u.PollingIntervalSeconds),
-		CreatedAt:     u.CreatedAt.Unix(),
+		CronExpr:               u.CronExpr,
+		Timezone:               u.Timezone,
+		CreatedAt:              u.CreatedAt.Unix(),
+		Paused:                 u.Paused,
+		NextRunAt:              u.NextRunAt.Unix(),
+		LastRunAt:              lastRunAt,
+		LastStatus:             u.LastStatus,
+		LastError:              u.LastError,
+		ConsecutiveFailures:    int32(u.ConsecutiveFailures),
+		DisabledAt:             disabledAt,
+		DisabledReason:         u.DisabledReason,
+		Priority:               int32(u.Priority),
+	}
+}
+
+func mapPublishFailure(f *models.PublishFailure) *users.PublishFailure {
+	return &users.PublishFailure{
+		Id:        f.ID,
+		EventId:   f.EventID,
+		UrlId:     f.URLID,
+		Topic:     f.Topic,
+		Key:       f.Key,
+		Payload:   f.Payload,
+		Headers:   f.Headers,
+		Attempts:  int32(f.Attempts),
+		LastError: f.LastError,
+		CreatedAt: f.CreatedAt.Unix(),
+		FailedAt:  f.FailedAt.Unix(),
 	}
 }
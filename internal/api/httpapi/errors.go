@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/LehaAlexey/Users/internal/services/userservice"
+)
+
+type errorResponse struct {
+	Error      string                       `json:"error"`
+	Violations []userservice.FieldViolation `json:"violations,omitempty"`
+}
+
+func writeServiceError(w http.ResponseWriter, err error) {
+	var svcErr *userservice.Error
+	if !errors.As(err, &svcErr) {
+		slog.Error("httpapi: unexpected error", "error", err.Error())
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Error: "internal error"})
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(svcErr, userservice.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(svcErr, userservice.ErrAlreadyExists), errors.Is(svcErr, userservice.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(svcErr, userservice.ErrInvalidArgument):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(svcErr, userservice.ErrRateLimited):
+		status = http.StatusTooManyRequests
+		if svcErr.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(svcErr.RetryAfter.Seconds())))
+		}
+	default:
+		slog.Error("httpapi: internal error", "error", err.Error())
+	}
+
+	writeJSON(w, status, errorResponse{Error: svcErr.Message, Violations: svcErr.Violations})
+}
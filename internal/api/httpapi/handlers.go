@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/LehaAlexey/Users/internal/models"
 	"github.com/LehaAlexey/Users/internal/services/userservice"
@@ -15,25 +16,58 @@ import (
 type Service interface {
 	CreateUser(ctx context.Context, req userservice.CreateUserRequest) (*models.User, error)
 	GetUser(ctx context.Context, userID string) (*models.User, error)
-	AddURL(ctx context.Context, userID string, rawURL string, intervalSeconds int) (*models.UserURL, error)
-	ListUserURLs(ctx context.Context, userID string, limit int) ([]models.UserURL, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	ListUsers(ctx context.Context, req userservice.ListUsersRequest) (*models.UserPage, error)
+	UpdateUser(ctx context.Context, userID string, req userservice.UpdateUserRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	RestoreUser(ctx context.Context, userID string) (*models.User, error)
+	AddURL(ctx context.Context, userID string, req userservice.AddURLRequest) (*models.UserURL, error)
+	ListUserURLs(ctx context.Context, userID string, req userservice.ListURLsRequest) (*models.URLPage, error)
+	UpdateURL(ctx context.Context, userID string, urlID string, req userservice.UpdateURLRequest) (*models.UserURL, error)
+	PauseURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error)
+	ResumeURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error)
+	DeleteURL(ctx context.Context, userID string, urlID string) error
+	TriggerParse(ctx context.Context, userID string, urlID string) (*models.ParseTrigger, error)
+	ListPublishFailures(ctx context.Context, afterID string, limit int) ([]models.PublishFailure, error)
+	ReplayPublishFailure(ctx context.Context, id string) error
+	DiscardPublishFailure(ctx context.Context, id string) error
+}
+
+type SchedulerStatus interface {
+	Status() models.SchedulerStatus
 }
 
 type Handler struct {
-	service Service
+	service   Service
+	scheduler SchedulerStatus
 }
 
-func New(service Service) *Handler {
-	return &Handler{service: service}
+func New(service Service, scheduler SchedulerStatus) *Handler {
+	return &Handler{service: service, scheduler: scheduler}
 }
 
 func (h *Handler) Routes() http.Handler {
 	r := chi.NewRouter()
+	r.Use(requestID)
 	r.Get("/health", h.Health)
+	r.Get("/scheduler/status", h.SchedulerStatus)
+	r.Get("/users", h.ListUsers)
 	r.Post("/users", h.CreateUser)
+	r.Get("/users/by-email", h.GetUserByEmail)
 	r.Get("/users/{id}", h.GetUser)
+	r.Patch("/users/{id}", h.UpdateUser)
+	r.Delete("/users/{id}", h.DeleteUser)
+	r.Post("/users/{id}/restore", h.RestoreUser)
 	r.Post("/users/{id}/urls", h.AddURL)
 	r.Get("/users/{id}/urls", h.ListUserURLs)
+	r.Patch("/users/{id}/urls/{urlId}", h.UpdateURL)
+	r.Delete("/users/{id}/urls/{urlId}", h.DeleteURL)
+	r.Post("/users/{id}/urls/{urlId}/pause", h.PauseURL)
+	r.Post("/users/{id}/urls/{urlId}/resume", h.ResumeURL)
+	r.Post("/users/{id}/urls/{urlId}/refresh", h.RefreshURL)
+	r.Get("/admin/publish-failures", h.ListPublishFailures)
+	r.Post("/admin/publish-failures/{id}/replay", h.ReplayPublishFailure)
+	r.Delete("/admin/publish-failures/{id}", h.DiscardPublishFailure)
 	return r
 }
 
@@ -41,6 +75,10 @@ func (h *Handler) Health(w http.ResponseWriter, _ *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+func (h *Handler) SchedulerStatus(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.scheduler.Status())
+}
+
 func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Email string `json:"email"`
@@ -55,7 +93,7 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		Name:  req.Name,
 	})
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeServiceError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusCreated, res)
@@ -65,7 +103,56 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	res, err := h.service.GetUser(r.Context(), id)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
+	res, err := h.service.GetUserByEmail(r.Context(), r.URL.Query().Get("email"))
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req struct {
+		Email *string `json:"email"`
+		Name  *string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	res, err := h.service.UpdateUser(r.Context(), id, userservice.UpdateUserRequest{
+		Email: req.Email,
+		Name:  req.Name,
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if err := h.service.DeleteUser(r.Context(), id); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	res, err := h.service.RestoreUser(r.Context(), id)
+	if err != nil {
+		writeServiceError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, res)
@@ -74,16 +161,25 @@ func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AddURL(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	var req struct {
-		URL                   string `json:"url"`
+		URL                    string `json:"url"`
 		PollingIntervalSeconds int    `json:"polling_interval_seconds"`
+		CronExpr               string `json:"cron_expr"`
+		Timezone               string `json:"timezone"`
+		Priority               int    `json:"priority"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid json")
 		return
 	}
-	res, err := h.service.AddURL(r.Context(), id, req.URL, req.PollingIntervalSeconds)
+	res, err := h.service.AddURL(r.Context(), id, userservice.AddURLRequest{
+		URL:                    req.URL,
+		PollingIntervalSeconds: req.PollingIntervalSeconds,
+		CronExpr:               req.CronExpr,
+		Timezone:               req.Timezone,
+		Priority:               req.Priority,
+	})
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeServiceError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusCreated, res)
@@ -91,18 +187,161 @@ func (h *Handler) AddURL(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handler) ListUserURLs(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	limit := parseIntDefault(r.URL.Query().Get("limit"), 100)
+	query := r.URL.Query()
+	limit := parseIntDefault(query.Get("limit"), 100)
 	if limit <= 0 {
 		limit = 100
 	}
-	res, err := h.service.ListUserURLs(r.Context(), id, limit)
+	page, err := h.service.ListUserURLs(r.Context(), id, userservice.ListURLsRequest{
+		Limit:  limit,
+		Cursor: query.Get("cursor"),
+		Host:   query.Get("host"),
+		Status: query.Get("status"),
+		Search: query.Get("search"),
+		Sort:   query.Get("sort"),
+	})
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	// The body stays a plain array; the next page is advertised in a header.
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, page.URLs)
+}
+
+func (h *Handler) UpdateURL(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	urlID := chi.URLParam(r, "urlId")
+	var req struct {
+		PollingIntervalSeconds int  `json:"polling_interval_seconds"`
+		Priority               *int `json:"priority"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json")
+		return
+	}
+	res, err := h.service.UpdateURL(r.Context(), id, urlID, userservice.UpdateURLRequest{
+		PollingIntervalSeconds: req.PollingIntervalSeconds,
+		Priority:               req.Priority,
+	})
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeServiceError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusOK, res)
 }
 
+func (h *Handler) PauseURL(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	urlID := chi.URLParam(r, "urlId")
+	res, err := h.service.PauseURL(r.Context(), id, urlID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) ResumeURL(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	urlID := chi.URLParam(r, "urlId")
+	res, err := h.service.ResumeURL(r.Context(), id, urlID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) RefreshURL(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	urlID := chi.URLParam(r, "urlId")
+	res, err := h.service.TriggerParse(r.Context(), id, urlID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, res)
+}
+
+func (h *Handler) DeleteURL(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	urlID := chi.URLParam(r, "urlId")
+	if err := h.service.DeleteURL(r.Context(), id, urlID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := userservice.ListUsersRequest{
+		Limit:  parseIntDefault(query.Get("limit"), 100),
+		Cursor: query.Get("cursor"),
+		Query:  query.Get("query"),
+	}
+	var err error
+	if req.CreatedFrom, err = parseTimeParam(query.Get("created_from"), "created_from"); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if req.CreatedTo, err = parseTimeParam(query.Get("created_to"), "created_to"); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	page, err := h.service.ListUsers(r.Context(), req)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, page.Users)
+}
+
+func (h *Handler) ListPublishFailures(w http.ResponseWriter, r *http.Request) {
+	limit := parseIntDefault(r.URL.Query().Get("limit"), 100)
+	res, err := h.service.ListPublishFailures(r.Context(), r.URL.Query().Get("after_id"), limit)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) ReplayPublishFailure(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.ReplayPublishFailure(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) DiscardPublishFailure(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DiscardPublishFailure(r.Context(), chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter.
+func parseTimeParam(raw string, field string) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, userservice.InvalidArgument(field, "must be an RFC 3339 timestamp")
+	}
+	return &t, nil
+}
+
 func parseIntDefault(raw string, def int) int {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -0,0 +1,15 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/LehaAlexey/Users/internal/correlation"
+)
+
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := correlation.Normalize(r.Header.Get(correlation.HeaderRequestID))
+		w.Header().Set(correlation.HeaderRequestID, id)
+		next.ServeHTTP(w, r.WithContext(correlation.WithID(r.Context(), id)))
+	})
+}
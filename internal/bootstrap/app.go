@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,6 +12,13 @@ import (
 	"github.com/LehaAlexey/Users/internal/api/grpcserver"
 	"github.com/LehaAlexey/Users/internal/api/httpapi"
 	"github.com/LehaAlexey/Users/internal/kafka"
+	"github.com/LehaAlexey/Users/internal/leader"
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+	"github.com/LehaAlexey/Users/internal/outbox"
+	"github.com/LehaAlexey/Users/internal/parseresults"
+	"github.com/LehaAlexey/Users/internal/publisher"
+	"github.com/LehaAlexey/Users/internal/purger"
 	"github.com/LehaAlexey/Users/internal/scheduler"
 	"github.com/LehaAlexey/Users/internal/services/userservice"
 	"github.com/LehaAlexey/Users/internal/storage/pgstorage"
@@ -20,9 +28,12 @@ import (
 )
 
 type App struct {
-	server    HTTPServerRunner
-	scheduler SchedulerRunner
+	server     HTTPServerRunner
+	scheduler  SchedulerRunner
 	grpcServer GRPCServerRunner
+	purger     PurgerRunner
+	relay      RelayRunner
+	results    ResultsConsumerRunner
 }
 
 func InitApp(configuration *config.Config) (*App, error) {
@@ -44,23 +55,175 @@ func InitApp(configuration *config.Config) (*App, error) {
 	}
 
 	storage := pgstorage.New(pool)
-	service := userservice.New(storage, configuration.Scheduler.DefaultIntervalSeconds)
-	handler := httpapi.New(service)
+	slots := scheduler.SlotPolicy{
+		JitterFraction: configuration.Scheduler.JitterFraction,
+		CronJitter:     time.Duration(configuration.Scheduler.CronJitterSeconds) * time.Second,
+		InitialSpread:  time.Duration(configuration.Scheduler.InitialSpreadSeconds) * time.Second,
+		CatchUp:        configuration.Scheduler.CatchUp,
+	}
+	codec := events.Codec{
+		Format:      configuration.Publisher.Format,
+		CloudEvents: configuration.Publisher.CloudEvents.Mode,
+		Source:      configuration.Publisher.CloudEvents.Source,
+	}
+	lanes := scheduler.Lanes{
+		Regular:   configuration.Kafka.ParseRequestedTopic,
+		High:      configuration.Kafka.ParseRequestedHighTopic,
+		Threshold: configuration.Scheduler.HighPriorityThreshold,
+	}
+	service := userservice.New(storage, configuration.Scheduler.DefaultIntervalSeconds, userservice.FailurePolicy{
+		DisableAfter:  configuration.Scheduler.DisableAfterFailures,
+		DisabledTopic: configuration.Kafka.URLDisabledTopic,
+	}, slots, userservice.TriggerPolicy{
+		Lanes:         lanes,
+		RatePerMinute: configuration.Trigger.RatePerMinute,
+		Burst:         configuration.Trigger.Burst,
+	}, userservice.EventPolicy{
+		Codec:       codec,
+		DomainTopic: configuration.Kafka.UserEventsTopic,
+	})
+
+	var sched SchedulerRunner = scheduler.New(storage, lanes, codec, time.Duration(configuration.Scheduler.TickSeconds)*time.Second, configuration.Scheduler.DefaultIntervalSeconds, configuration.Scheduler.MaxBatch, time.Duration(configuration.Scheduler.ClaimLeaseSeconds)*time.Second, scheduler.Backoff{
+		MaxSeconds: configuration.Scheduler.BackoffMaxSeconds,
+		Jitter:     configuration.Scheduler.BackoffJitter,
+	}, slots, newHostLimiter(configuration.Scheduler.RateLimit), newFairnessPolicy(configuration.Scheduler.Fairness))
+	if configuration.Scheduler.Mode == models.SchedulerModeLeader {
+		sched = leader.New(pool, configuration.Scheduler.LeaderLockKey, time.Duration(configuration.Scheduler.LeaderRetrySeconds)*time.Second, sched)
+	}
+
+	handler := httpapi.New(service, sched)
 
 	router := chi.NewRouter()
 	router.Mount("/", handler.Routes())
+	router.Handle("/debug/vars", expvar.Handler())
 	mountSwagger(router, configuration)
 	server := NewHTTPServer(configuration.HTTP.Addr, router)
 
-	grpcSrv := grpc.NewServer()
+	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(grpcserver.CorrelationInterceptor))
 	grpcHandler := grpcserver.New(service)
 	grpcServer := NewGRPCServer(configuration.GRPC.Addr, grpcSrv, grpcHandler)
 
-	kafkaBrokers := []string{fmt.Sprintf("%s:%d", configuration.Kafka.Host, configuration.Kafka.Port)}
-	writer := kafka.NewWriter(kafkaBrokers, configuration.Kafka.ParseRequestedTopic)
-	sched := scheduler.New(storage, writer, time.Duration(configuration.Scheduler.TickSeconds)*time.Second, configuration.Scheduler.DefaultIntervalSeconds, configuration.Scheduler.MaxBatch)
+	kafkaOpts, err := newKafkaOptions(configuration.Kafka)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := newPublisher(ctx, configuration, kafkaOpts, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	purge := purger.New(storage, time.Duration(configuration.Purge.TickSeconds)*time.Second, configuration.Purge.RetentionSeconds, configuration.Purge.MaxBatch)
+
+	relay := outbox.New(
+		storage,
+		pub,
+		service,
+		time.Duration(configuration.Outbox.TickMillis)*time.Millisecond,
+		configuration.Outbox.BatchSize,
+		time.Duration(configuration.Outbox.LeaseSeconds)*time.Second,
+		time.Duration(configuration.Outbox.RetryBaseMillis)*time.Millisecond,
+		time.Duration(configuration.Outbox.RetryMaxSeconds)*time.Second,
+		configuration.Outbox.MaxAttempts,
+		configuration.Outbox.RetentionSeconds,
+	)
+
+	// Parse results arrive over Kafka; with other backends parsers report them
+	// through the ReportParseResult RPC.
+	var results ResultsConsumerRunner
+	if backend := configuration.Publisher.Backend; backend == "" || backend == publisher.BackendKafka {
+		resultTopics := []string{configuration.Kafka.ParseCompletedTopic, configuration.Kafka.ParseFailedTopic}
+		reader := kafka.NewReader(kafkaOpts, configuration.Kafka.ConsumerGroup, resultTopics)
+		results = parseresults.New(reader, service, configuration.Kafka.ParseCompletedTopic, configuration.Kafka.ParseFailedTopic)
+	}
+
+	return &App{server: server, scheduler: sched, grpcServer: grpcServer, purger: purge, relay: relay, results: results}, nil
+}
+
+func newHostLimiter(cfg config.RateLimitConfig) *scheduler.HostLimiter {
+	overrides := make(map[string]scheduler.HostLimit, len(cfg.Domains))
+	for domain, limit := range cfg.Domains {
+		overrides[domain] = scheduler.HostLimit{RatePerSecond: limit.RatePerSecond, Burst: limit.Burst}
+	}
+	return scheduler.NewHostLimiter(scheduler.HostLimit{RatePerSecond: cfg.RatePerSecond, Burst: cfg.Burst}, overrides)
+}
+
+func newFairnessPolicy(cfg config.FairnessConfig) models.FairnessPolicy {
+	plans := make(map[string]models.PlanQuota, len(cfg.Plans))
+	for plan, quota := range cfg.Plans {
+		plans[plan] = models.PlanQuota{MaxPerBatch: quota.MaxPerBatch, Weight: quota.Weight}
+	}
+	return models.FairnessPolicy{
+		Default: models.PlanQuota{MaxPerBatch: cfg.MaxPerBatch, Weight: cfg.Weight},
+		Plans:   plans,
+	}
+}
 
-	return &App{server: server, scheduler: sched, grpcServer: grpcServer}, nil
+func newKafkaOptions(cfg config.KafkaConfig) (kafka.Options, error) {
+	opts := kafka.Options{
+		Brokers:      cfg.BrokerList(),
+		BatchSize:    cfg.BatchSize,
+		BatchBytes:   cfg.BatchBytes,
+		BatchTimeout: time.Duration(cfg.BatchTimeoutMillis) * time.Millisecond,
+		MaxAttempts:  cfg.MaxAttempts,
+		BackoffMin:   time.Duration(cfg.RetryBackoffMinMillis) * time.Millisecond,
+		BackoffMax:   time.Duration(cfg.RetryBackoffMaxMillis) * time.Millisecond,
+	}
+	var err error
+	if opts.RequiredAcks, err = kafka.ParseAcks(cfg.Acks); err != nil {
+		return kafka.Options{}, fmt.Errorf("kafka: %w", err)
+	}
+	if opts.Compression, err = kafka.ParseCompression(cfg.Compression); err != nil {
+		return kafka.Options{}, fmt.Errorf("kafka: %w", err)
+	}
+	if cfg.TLS.Enabled {
+		if opts.TLS, err = kafka.LoadTLS(cfg.TLS.CAFile, cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.InsecureSkipVerify); err != nil {
+			return kafka.Options{}, fmt.Errorf("kafka tls: %w", err)
+		}
+	}
+	if cfg.SASL.Mechanism != "" {
+		if opts.SASL, err = kafka.NewSASL(cfg.SASL.Mechanism, cfg.SASL.Username, cfg.SASL.Password); err != nil {
+			return kafka.Options{}, fmt.Errorf("kafka sasl: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+func newPublisher(ctx context.Context, configuration *config.Config, kafkaOpts kafka.Options, pool *pgxpool.Pool) (publisher.Publisher, error) {
+	switch configuration.Publisher.Backend {
+	case publisher.BackendNATS:
+		// Optional topics such as the high-priority lane may be left empty.
+		var subjects []string
+		for _, topic := range []string{
+			configuration.Kafka.ParseRequestedTopic,
+			configuration.Kafka.ParseRequestedHighTopic,
+			configuration.Kafka.URLDisabledTopic,
+			configuration.Kafka.UserEventsTopic,
+		} {
+			if topic != "" {
+				subjects = append(subjects, topic)
+			}
+		}
+		pub, err := publisher.NewNATS(ctx, configuration.Publisher.NATS.URL, configuration.Publisher.NATS.Stream, subjects)
+		if err != nil {
+			return nil, fmt.Errorf("publisher: %w", err)
+		}
+		return pub, nil
+	case publisher.BackendPostgres:
+		return publisher.NewPostgres(pool), nil
+	case publisher.BackendWebhook:
+		cfg := configuration.Publisher.Webhook
+		return publisher.NewWebhook(cfg.URL, time.Duration(cfg.TimeoutSeconds)*time.Second, cfg.Headers), nil
+	case publisher.BackendStdout:
+		return publisher.NewStdout(), nil
+	case publisher.BackendFile:
+		pub, err := publisher.NewFile(configuration.Publisher.File.Path)
+		if err != nil {
+			return nil, fmt.Errorf("publisher: %w", err)
+		}
+		return pub, nil
+	default:
+		return publisher.NewKafka(kafka.NewWriter(kafkaOpts)), nil
+	}
 }
 
 type HTTPServerRunner interface {
@@ -69,12 +232,25 @@ type HTTPServerRunner interface {
 
 type SchedulerRunner interface {
 	Run(ctx context.Context) error
+	Status() models.SchedulerStatus
 }
 
 type GRPCServerRunner interface {
 	Run(ctx context.Context) error
 }
 
+type PurgerRunner interface {
+	Run(ctx context.Context) error
+}
+
+type RelayRunner interface {
+	Run(ctx context.Context) error
+}
+
+type ResultsConsumerRunner interface {
+	Run(ctx context.Context) error
+}
+
 func mountSwagger(router chi.Router, configuration *config.Config) {
 	if configuration == nil || !configuration.Swagger.Enabled {
 		return
@@ -6,7 +6,7 @@ import (
 )
 
 func (a *App) Run(ctx context.Context) error {
-	errCh := make(chan error, 3)
+	errCh := make(chan error, 6)
 
 	go func() {
 		if err := a.server.Run(ctx); err != nil {
@@ -26,6 +26,26 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		if err := a.purger.Run(ctx); err != nil {
+			errCh <- err
+		}
+	}()
+
+	go func() {
+		if err := a.relay.Run(ctx); err != nil {
+			errCh <- err
+		}
+	}()
+
+	if a.results != nil {
+		go func() {
+			if err := a.results.Run(ctx); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
 	select {
 	case <-ctx.Done():
 		return nil
@@ -0,0 +1,45 @@
+package correlation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	HeaderRequestID   = "X-Request-ID"
+	MetadataRequestID = "x-request-id"
+
+	maxIDLength = 128
+)
+
+type ctxKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+func NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// Normalize returns the caller-supplied id if it is safe to log and forward,
+// otherwise a freshly generated one.
+func Normalize(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" || len(id) > maxIDLength {
+		return NewID()
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return NewID()
+		}
+	}
+	return id
+}
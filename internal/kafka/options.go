@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// Options are the connection and producer settings shared by writers and
+// readers. Zero values fall back to the kafka-go defaults; RequiredAcks is
+// used as is, since its zero value means RequireNone (see ParseAcks).
+type Options struct {
+	Brokers      []string
+	TLS          *tls.Config
+	SASL         sasl.Mechanism
+	RequiredAcks kafka.RequiredAcks
+	Compression  kafka.Compression
+	BatchSize    int
+	BatchBytes   int64
+	BatchTimeout time.Duration
+	// kafka-go has no idempotent producer: a retried batch may be written
+	// twice, which consumers absorb through event ids and idempotency keys.
+	MaxAttempts int
+	BackoffMin  time.Duration
+	BackoffMax  time.Duration
+}
+
+// LoadTLS builds a client TLS config. caFile adds a trusted CA; certFile and
+// keyFile, set together, enable client certificate authentication.
+func LoadTLS(caFile string, certFile string, keyFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: insecureSkipVerify}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca file %s: no certificates found", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// NewSASL returns the mechanism for plain, scram-sha-256 or scram-sha-512.
+func NewSASL(mechanism string, username string, password string) (sasl.Mechanism, error) {
+	switch strings.ToLower(mechanism) {
+	case "plain":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unknown sasl mechanism %q", mechanism)
+	}
+}
+
+// ParseAcks maps none, one and all to kafka-go's RequiredAcks; empty is one.
+func ParseAcks(acks string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(acks) {
+	case "", "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	case "all":
+		return kafka.RequireAll, nil
+	default:
+		return 0, fmt.Errorf("unknown acks %q", acks)
+	}
+}
+
+// ParseCompression maps a codec name to kafka-go's Compression; empty and
+// none disable compression.
+func ParseCompression(codec string) (kafka.Compression, error) {
+	switch strings.ToLower(codec) {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q", codec)
+	}
+}
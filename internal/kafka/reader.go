@@ -0,0 +1,32 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+func NewReader(opts Options, groupID string, topics []string) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: opts.Brokers,
+		Dialer: &kafka.Dialer{
+			Timeout:       10 * time.Second,
+			DualStack:     true,
+			TLS:           opts.TLS,
+			SASLMechanism: opts.SASL,
+		},
+		GroupID:        groupID,
+		GroupTopics:    topics,
+		MinBytes:       1,
+		MaxBytes:       10e6,
+		MaxWait:        time.Second,
+		CommitInterval: 0,
+	})
+}
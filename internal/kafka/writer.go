@@ -12,15 +12,23 @@ type Writer interface {
 	Close() error
 }
 
-func NewWriter(brokers []string, topic string) *kafka.Writer {
+// NewWriter returns a writer without a default topic: every message carries
+// its own Topic, so one writer serves all outbox destinations.
+func NewWriter(opts Options) *kafka.Writer {
 	return &kafka.Writer{
-		Addr:                   kafka.TCP(brokers...),
-		Topic:                  topic,
-		RequiredAcks:           kafka.RequireOne,
+		Addr:                   kafka.TCP(opts.Brokers...),
+		Transport:              &kafka.Transport{TLS: opts.TLS, SASL: opts.SASL},
+		RequiredAcks:           opts.RequiredAcks,
 		AllowAutoTopicCreation: true,
 		Balancer:               &kafka.Hash{},
+		Compression:            opts.Compression,
+		BatchSize:              opts.BatchSize,
+		BatchBytes:             opts.BatchBytes,
+		BatchTimeout:           opts.BatchTimeout,
+		MaxAttempts:            opts.MaxAttempts,
+		WriteBackoffMin:        opts.BackoffMin,
+		WriteBackoffMax:        opts.BackoffMax,
 		WriteTimeout:           10 * time.Second,
 		ReadTimeout:            10 * time.Second,
 	}
 }
-
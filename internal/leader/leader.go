@@ -0,0 +1,136 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// Elector runs the wrapped Runner only while this instance holds a Postgres
+// session-level advisory lock. The lock lives on a connection taken out of the
+// pool for the whole term, so if that session dies Postgres releases the lock
+// and another instance takes over on its next attempt.
+type Elector struct {
+	pool          *pgxpool.Pool
+	lockKey       int64
+	retryInterval time.Duration
+	runner        Runner
+	leader        atomic.Bool
+}
+
+func New(pool *pgxpool.Pool, lockKey int64, retryInterval time.Duration, runner Runner) *Elector {
+	if retryInterval <= 0 {
+		retryInterval = 5 * time.Second
+	}
+	return &Elector{pool: pool, lockKey: lockKey, retryInterval: retryInterval, runner: runner}
+}
+
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+func (e *Elector) Status() models.SchedulerStatus {
+	isLeader := e.IsLeader()
+	return models.SchedulerStatus{Mode: models.SchedulerModeLeader, Leader: isLeader, Active: isLeader}
+}
+
+func (e *Elector) Run(ctx context.Context) error {
+	for {
+		if err := e.campaign(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.retryInterval):
+		}
+	}
+}
+
+// campaign tries to take the lock once and, on success, leads until the
+// session is lost or ctx is cancelled. A nil error means "try again later".
+func (e *Elector) campaign(ctx context.Context) error {
+	conn, err := e.pool.Acquire(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.Error("leader: acquire connection", "error", err.Error())
+		return nil
+	}
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", e.lockKey).Scan(&acquired); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.Error("leader: try lock", "error", err.Error())
+		return nil
+	}
+	if !acquired {
+		return nil
+	}
+
+	slog.Info("leader: acquired scheduler leadership", "lock_key", e.lockKey)
+	e.leader.Store(true)
+	defer e.leader.Store(false)
+
+	termCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- e.runner.Run(termCtx)
+	}()
+
+	ticker := time.NewTicker(e.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-runErr
+			e.unlock(conn)
+			return ctx.Err()
+		case err := <-runErr:
+			e.unlock(conn)
+			if err != nil && !errors.Is(err, context.Canceled) {
+				return err
+			}
+			return nil
+		case <-ticker.C:
+			pingCtx, pingCancel := context.WithTimeout(ctx, e.retryInterval)
+			err := conn.Ping(pingCtx)
+			pingCancel()
+			if err == nil {
+				continue
+			}
+			slog.Error("leader: lost lock session", "error", err.Error())
+			cancel()
+			<-runErr
+			_ = conn.Conn().Close(context.Background())
+			return nil
+		}
+	}
+}
+
+func (e *Elector) unlock(conn *pgxpool.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", e.lockKey); err != nil {
+		slog.Error("leader: unlock", "error", err.Error())
+		_ = conn.Conn().Close(ctx)
+	}
+}
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CloudEvents 1.0 content modes of the Kafka protocol binding. In binary mode
+// the payload is unchanged and the attributes travel as ce_* headers; in
+// structured mode the payload is wrapped in a JSON envelope.
+const (
+	CloudEventsBinary     = "binary"
+	CloudEventsStructured = "structured"
+
+	ContentTypeCloudEvents = "application/cloudevents+json"
+)
+
+// CloudEvents types of the events emitted by the service.
+const (
+	TypeParseRequested = "com.lehaalexey.users.parse_requested.v1"
+	TypeURLDisabled    = "com.lehaalexey.users.url_disabled.v1"
+)
+
+// Codec encodes events for publishing: the wire format of ParseRequested and
+// an optional CloudEvents envelope around every event. The zero value emits
+// plain JSON.
+type Codec struct {
+	Format      string
+	CloudEvents string
+	Source      string
+}
+
+// Meta holds the CloudEvents context attributes of one event.
+type Meta struct {
+	ID      string
+	Type    string
+	Subject string
+	Time    time.Time
+}
+
+func (c Codec) ParseRequested(e ParseRequested) ([]byte, map[string]string, error) {
+	payload, headers, err := e.Encode(c.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.wrap(Meta{ID: e.EventID, Type: TypeParseRequested, Subject: e.ProductID, Time: e.OccurredAt}, payload, headers)
+}
+
+// JSON encodes an event that has only a JSON representation.
+func (c Codec) JSON(meta Meta, v any) ([]byte, map[string]string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal %s: %w", meta.Type, err)
+	}
+	return c.wrap(meta, payload, map[string]string{HeaderContentType: ContentTypeJSON})
+}
+
+type structuredEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            time.Time       `json:"time"`
+	Subject         string          `json:"subject,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	SchemaVersion   string          `json:"schemaversion,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      []byte          `json:"data_base64,omitempty"`
+}
+
+func (c Codec) wrap(meta Meta, payload []byte, headers map[string]string) ([]byte, map[string]string, error) {
+	source := c.Source
+	if source == "" {
+		source = "/users-service"
+	}
+
+	switch c.CloudEvents {
+	case "":
+		return payload, headers, nil
+	case CloudEventsBinary:
+		headers["ce_specversion"] = "1.0"
+		headers["ce_id"] = meta.ID
+		headers["ce_source"] = source
+		headers["ce_type"] = meta.Type
+		headers["ce_time"] = meta.Time.UTC().Format(time.RFC3339Nano)
+		if meta.Subject != "" {
+			headers["ce_subject"] = meta.Subject
+		}
+		if v, ok := headers[HeaderSchemaVersion]; ok {
+			headers["ce_schemaversion"] = v
+		}
+		return payload, headers, nil
+	case CloudEventsStructured:
+		env := structuredEvent{
+			SpecVersion:     "1.0",
+			ID:              meta.ID,
+			Source:          source,
+			Type:            meta.Type,
+			Time:            meta.Time.UTC(),
+			Subject:         meta.Subject,
+			DataContentType: headers[HeaderContentType],
+			SchemaVersion:   headers[HeaderSchemaVersion],
+		}
+		if env.DataContentType == ContentTypeJSON {
+			env.Data = payload
+		} else {
+			env.DataBase64 = payload
+		}
+		wrapped, err := json.Marshal(&env)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal cloudevent: %w", err)
+		}
+		headers[HeaderContentType] = ContentTypeCloudEvents
+		return wrapped, headers, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown cloudevents mode %q", c.CloudEvents)
+	}
+}
@@ -0,0 +1,68 @@
+package events
+
+import "time"
+
+// Domain event names, carried in the Type field of UserEvent and URLEvent.
+const (
+	UserCreated  = "UserCreated"
+	UserUpdated  = "UserUpdated"
+	UserDeleted  = "UserDeleted"
+	UserRestored = "UserRestored"
+	URLAdded     = "UrlAdded"
+	URLUpdated   = "UrlUpdated"
+	URLRemoved   = "UrlRemoved"
+)
+
+// UserEvent announces a change to a user. It carries the user as it is after
+// the change; for UserDeleted, as it was when deleted.
+type UserEvent struct {
+	EventID       string    `json:"event_id"`
+	Type          string    `json:"type"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	CorrelationID string    `json:"correlation_id"`
+	UserID        string    `json:"user_id"`
+	Email         string    `json:"email"`
+	Name          string    `json:"name"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// URLEvent announces a change to a tracked URL, including pause and resume.
+// It carries the URL as it is after the change; for UrlRemoved, as it was
+// when removed.
+type URLEvent struct {
+	EventID                string    `json:"event_id"`
+	Type                   string    `json:"type"`
+	OccurredAt             time.Time `json:"occurred_at"`
+	CorrelationID          string    `json:"correlation_id"`
+	URLID                  string    `json:"url_id"`
+	UserID                 string    `json:"user_id"`
+	URL                    string    `json:"url"`
+	NormalizedURL          string    `json:"normalized_url"`
+	PollingIntervalSeconds int       `json:"polling_interval_seconds"`
+	CronExpr               string    `json:"cron_expr,omitempty"`
+	Timezone               string    `json:"timezone,omitempty"`
+	Priority               int       `json:"priority"`
+	Paused                 bool      `json:"paused"`
+}
+
+// CloudEventType returns the CloudEvents type of a domain event name.
+func CloudEventType(name string) string {
+	switch name {
+	case UserCreated:
+		return "com.lehaalexey.users.user_created.v1"
+	case UserUpdated:
+		return "com.lehaalexey.users.user_updated.v1"
+	case UserDeleted:
+		return "com.lehaalexey.users.user_deleted.v1"
+	case UserRestored:
+		return "com.lehaalexey.users.user_restored.v1"
+	case URLAdded:
+		return "com.lehaalexey.users.url_added.v1"
+	case URLUpdated:
+		return "com.lehaalexey.users.url_updated.v1"
+	case URLRemoved:
+		return "com.lehaalexey.users.url_removed.v1"
+	default:
+		return "com.lehaalexey.users." + name
+	}
+}
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// idempotencyNamespace scopes the name-based UUIDs produced by IdempotencyKey.
+var idempotencyNamespace = uuid.MustParse("e87dc48f-f36b-4270-b490-bef31b0c0978")
+
+// NewEventID returns a time-ordered UUIDv7, unique across replicas.
+func NewEventID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// IdempotencyKey is the same for every event emitted for one URL run: it is
+// derived from the URL id and the slot the run was planned for, so consumers
+// can drop redeliveries and duplicate publishes.
+func IdempotencyKey(urlID string, slot time.Time) string {
+	name := urlID + "|" + slot.UTC().Format(time.RFC3339Nano)
+	return uuid.NewSHA1(idempotencyNamespace, []byte(name)).String()
+}
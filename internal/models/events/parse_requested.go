@@ -1,14 +1,79 @@
 package events
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/pb/users"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ParseRequestedSchemaVersion is bumped on incompatible changes to the
+// event; consumers read it from the schema-version header.
+const ParseRequestedSchemaVersion = 1
+
+// Wire formats of ParseRequested. JSON stays the default so existing
+// consumers keep working; protobuf follows pb/users/parse_requested.proto.
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+)
+
+const (
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "schema-version"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
 
 type ParseRequested struct {
-	EventID       string    `json:"event_id"`
-	OccurredAt    time.Time `json:"occurred_at"`
-	CorrelationID string    `json:"correlation_id"`
-	ProductID     string    `json:"product_id,omitempty"`
-	URL           string    `json:"url"`
-	ScheduledAt   time.Time `json:"scheduled_at,omitempty"`
-	Priority      int       `json:"priority,omitempty"`
+	EventID        string    `json:"event_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	CorrelationID  string    `json:"correlation_id"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	ProductID      string    `json:"product_id,omitempty"`
+	URL            string    `json:"url"`
+	ScheduledAt    time.Time `json:"scheduled_at,omitempty"`
+	Priority       int       `json:"priority,omitempty"`
+	SchemaVersion  int       `json:"schema_version"`
 }
 
+// Encode serializes the event in the given wire format and returns the
+// headers that describe the payload.
+func (e ParseRequested) Encode(format string) ([]byte, map[string]string, error) {
+	e.SchemaVersion = ParseRequestedSchemaVersion
+	headers := map[string]string{HeaderSchemaVersion: strconv.Itoa(e.SchemaVersion)}
+
+	switch format {
+	case "", FormatJSON:
+		payload, err := json.Marshal(&e)
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal parse requested: %w", err)
+		}
+		headers[HeaderContentType] = ContentTypeJSON
+		return payload, headers, nil
+	case FormatProtobuf:
+		payload, err := proto.Marshal(&users.ParseRequested{
+			EventId:        e.EventID,
+			OccurredAt:     timestamppb.New(e.OccurredAt),
+			CorrelationId:  e.CorrelationID,
+			IdempotencyKey: e.IdempotencyKey,
+			ProductId:      e.ProductID,
+			Url:            e.URL,
+			ScheduledAt:    timestamppb.New(e.ScheduledAt),
+			Priority:       int32(e.Priority),
+			SchemaVersion:  int32(e.SchemaVersion),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("marshal parse requested: %w", err)
+		}
+		headers[HeaderContentType] = ContentTypeProtobuf
+		return payload, headers, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown event format %q", format)
+	}
+}
@@ -0,0 +1,24 @@
+package events
+
+import "time"
+
+// ParseCompleted and ParseFailed are published by the parser service in reply
+// to ParseRequested; ProductID and IdempotencyKey echo the request.
+type ParseCompleted struct {
+	EventID        string    `json:"event_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	CorrelationID  string    `json:"correlation_id"`
+	IdempotencyKey string    `json:"idempotency_key,omitempty"`
+	ProductID      string    `json:"product_id"`
+	URL            string    `json:"url,omitempty"`
+}
+
+type ParseFailed struct {
+	EventID        string    `json:"event_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	CorrelationID  string    `json:"correlation_id"`
+	IdempotencyKey string    `json:"idempotency_key,omitempty"`
+	ProductID      string    `json:"product_id"`
+	URL            string    `json:"url,omitempty"`
+	Error          string    `json:"error"`
+}
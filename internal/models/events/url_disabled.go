@@ -0,0 +1,17 @@
+package events
+
+import "time"
+
+// URLDisabled is emitted when a URL is paused automatically after too many
+// consecutive failed runs, so the owner can be notified.
+type URLDisabled struct {
+	EventID             string    `json:"event_id"`
+	OccurredAt          time.Time `json:"occurred_at"`
+	CorrelationID       string    `json:"correlation_id"`
+	URLID               string    `json:"url_id"`
+	UserID              string    `json:"user_id"`
+	URL                 string    `json:"url"`
+	Reason              string    `json:"reason"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
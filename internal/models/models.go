@@ -9,11 +9,190 @@ type User struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// UserSummary is a user as listed to administrators, with the number of URLs
+// they track.
+type UserSummary struct {
+	User
+	URLCount int `json:"url_count"`
+}
+
+// UserListQuery selects one page of active users, newest first. Query matches
+// an email prefix or a name substring, case-insensitively; CreatedFrom is
+// inclusive and CreatedTo exclusive.
+type UserListQuery struct {
+	Limit       int
+	Query       string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	After       *ListPosition
+}
+
+type UserPage struct {
+	Users      []UserSummary `json:"users"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
 type UserURL struct {
-	ID            string    `json:"id"`
-	UserID        string    `json:"user_id"`
-	URL           string    `json:"url"`
-	NormalizedURL string    `json:"normalized_url"`
-	PollingIntervalSeconds int `json:"polling_interval_seconds"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID                     string     `json:"id"`
+	UserID                 string     `json:"user_id"`
+	URL                    string     `json:"url"`
+	NormalizedURL          string     `json:"normalized_url"`
+	PollingIntervalSeconds int        `json:"polling_interval_seconds"`
+	CronExpr               string     `json:"cron_expr,omitempty"`
+	Timezone               string     `json:"timezone,omitempty"`
+	Paused                 bool       `json:"paused"`
+	CreatedAt              time.Time  `json:"created_at"`
+	NextRunAt              time.Time  `json:"next_run_at"`
+	LastRunAt              *time.Time `json:"last_run_at,omitempty"`
+	LastStatus             string     `json:"last_status,omitempty"`
+	LastError              string     `json:"last_error,omitempty"`
+	ConsecutiveFailures    int        `json:"consecutive_failures"`
+	DisabledAt             *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason         string     `json:"disabled_reason,omitempty"`
+	Priority               int        `json:"priority"`
+	ClaimID                string     `json:"-"`
+	CorrelationID          string     `json:"-"`
+	DeferredUntil          *time.Time `json:"-"`
+}
+
+// URL list sort keys and status filters.
+const (
+	URLSortCreatedAt = "created_at"
+	URLSortNextRunAt = "next_run_at"
+	URLSortURL       = "url"
+
+	URLStatusActive   = "active"
+	URLStatusPaused   = "paused"
+	URLStatusDisabled = "disabled"
+)
+
+// URLListQuery selects one page of a user's URLs. Empty filters match
+// everything.
+type URLListQuery struct {
+	UserID string
+	Limit  int
+	Host   string
+	Status string
+	Search string
+	Sort   string
+	Desc   bool
+	After  *ListPosition
+}
+
+// ListPosition is the keyset position of the last row of the previous page:
+// its sort value (RFC 3339 for timestamps) and ID.
+type ListPosition struct {
+	Value string
+	ID    string
+}
+
+type URLPage struct {
+	URLs       []UserURL `json:"urls"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+const (
+	RunStatusSucceeded     = "succeeded"
+	RunStatusFailed        = "failed"
+	RunStatusPublishFailed = "publish_failed"
+)
+
+type RunResult struct {
+	URLID         string
+	EventID       string
+	CorrelationID string
+	Status        string
+	Error         string
+	FinishedAt    time.Time
+}
+
+type OutboxMessage struct {
+	ID        int64
+	EventID   string
+	URLID     string
+	Topic     string
+	Key       []byte
+	Payload   []byte
+	Headers   map[string]string
+	Attempts  int
+	CreatedAt time.Time
+}
+
+const (
+	SchedulerModeClaim  = "claim"
+	SchedulerModeLeader = "leader"
+)
+
+// PublishFailure is an outbox message that exhausted its publish attempts.
+type PublishFailure struct {
+	ID        int64             `json:"id"`
+	EventID   string            `json:"event_id"`
+	URLID     string            `json:"url_id,omitempty"`
+	Topic     string            `json:"topic"`
+	Key       string            `json:"key,omitempty"`
+	Payload   []byte            `json:"payload"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Attempts  int               `json:"attempts"`
+	LastError string            `json:"last_error,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	FailedAt  time.Time         `json:"failed_at"`
+}
+
+// UserEventFunc and URLEventFunc build the outbox message announcing a change
+// to a user or a tracked URL. Storage calls them inside the transaction of the
+// change.
+type (
+	UserEventFunc func(User) (OutboxMessage, error)
+	URLEventFunc  func(UserURL) (OutboxMessage, error)
+)
+
+// ScheduledRun releases a claimed URL after its parse request was built:
+// next_run_at moves to NextRunAt and Event is queued in the outbox.
+type ScheduledRun struct {
+	URLID     string
+	ClaimID   string
+	NextRunAt time.Time
+	Event     OutboxMessage
+}
+
+// DeferredRun releases a claimed URL without running it until Until.
+type DeferredRun struct {
+	URLID   string
+	ClaimID string
+	Until   time.Time
+}
+
+// ParseTrigger is the receipt for an on-demand parse request. CorrelationID
+// is carried by the ParseRequested event and by the parse result.
+type ParseTrigger struct {
+	URLID         string    `json:"url_id"`
+	EventID       string    `json:"event_id"`
+	CorrelationID string    `json:"correlation_id"`
+	RequestedAt   time.Time `json:"requested_at"`
+}
+
+// URL priorities range from PriorityNormal to PriorityMax; higher values are
+// claimed first.
+const (
+	PriorityNormal = 0
+	PriorityMax    = 9
+)
+
+type SchedulerStatus struct {
+	Mode   string `json:"mode"`
+	Leader bool   `json:"leader"`
+	Active bool   `json:"active"`
+}
+
+// PlanQuota bounds how many due URLs of a single user are claimed per batch
+// and how much of the batch the user gets relative to others.
+type PlanQuota struct {
+	MaxPerBatch int
+	Weight      int
+}
+
+// FairnessPolicy maps users.plan to its quota; unknown plans use Default.
+type FairnessPolicy struct {
+	Default PlanQuota
+	Plans   map[string]PlanQuota
 }
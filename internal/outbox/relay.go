@@ -0,0 +1,194 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/publisher"
+)
+
+type Storage interface {
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, ids []int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error
+	MarkOutboxDead(ctx context.Context, id int64, lastError string) error
+	DeleteSentOutbox(ctx context.Context, retentionSeconds int, limit int) (int64, error)
+}
+
+// FailureReporter is told about the first failed publish of a message that
+// belongs to a URL, so the failure counts towards the URL's backoff.
+type FailureReporter interface {
+	RecordRunResult(ctx context.Context, result models.RunResult) error
+}
+
+// Relay publishes messages stored in the outbox table. A message is marked
+// sent only after the publisher acknowledged it, so delivery is at-least-once and
+// a retried message keeps the event ID it was stored with. After maxAttempts
+// failed publishes a message is moved to publish_failures, so a persistently
+// failing destination does not keep retrying forever.
+type Relay struct {
+	storage      Storage
+	publisher    publisher.Publisher
+	reporter     FailureReporter
+	tick         time.Duration
+	batchSize    int
+	lease        time.Duration
+	retryBase    time.Duration
+	retryMax     time.Duration
+	maxAttempts  int
+	retentionSec int
+}
+
+func New(storage Storage, pub publisher.Publisher, reporter FailureReporter, tick time.Duration, batchSize int, lease time.Duration, retryBase time.Duration, retryMax time.Duration, maxAttempts int, retentionSeconds int) *Relay {
+	if tick <= 0 {
+		tick = time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if lease <= 0 {
+		lease = 30 * time.Second
+	}
+	if retryBase <= 0 {
+		retryBase = time.Second
+	}
+	if retryMax <= 0 {
+		retryMax = 5 * time.Minute
+	}
+	if retentionSeconds <= 0 {
+		retentionSeconds = 24 * 3600
+	}
+	return &Relay{
+		storage:      storage,
+		publisher:    pub,
+		reporter:     reporter,
+		tick:         tick,
+		batchSize:    batchSize,
+		lease:        lease,
+		retryBase:    retryBase,
+		retryMax:     retryMax,
+		maxAttempts:  maxAttempts,
+		retentionSec: retentionSeconds,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			r.runOnce(ctx)
+			r.cleanup(ctx)
+		}
+	}
+}
+
+func (r *Relay) runOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		items, err := r.storage.ClaimOutbox(ctx, r.batchSize, r.lease)
+		if err != nil {
+			slog.Error("outbox: claim", "error", err.Error())
+			return
+		}
+		if len(items) == 0 {
+			return
+		}
+
+		// A short batch does not mean the outbox is drained: messages queued
+		// behind a key just published become claimable only now.
+		r.publish(ctx, items)
+	}
+}
+
+func (r *Relay) publish(ctx context.Context, items []models.OutboxMessage) {
+	msgs := make([]publisher.Message, 0, len(items))
+	for _, item := range items {
+		msgs = append(msgs, publisher.Message{
+			ID:      item.EventID,
+			Topic:   item.Topic,
+			Key:     item.Key,
+			Value:   item.Payload,
+			Headers: item.Headers,
+		})
+	}
+
+	errs := make([]error, len(items))
+	if err := r.publisher.Publish(ctx, msgs...); err != nil {
+		var pubErrs publisher.Errors
+		if errors.As(err, &pubErrs) && len(pubErrs) == len(items) {
+			copy(errs, pubErrs)
+		} else {
+			for i := range errs {
+				errs[i] = err
+			}
+		}
+	}
+
+	sent := make([]int64, 0, len(items))
+	for i, item := range items {
+		if errs[i] == nil {
+			sent = append(sent, item.ID)
+			continue
+		}
+		slog.Error("outbox: publish", "event_id", item.EventID, "attempts", item.Attempts+1, "error", errs[i].Error())
+		r.reportFailure(ctx, item, errs[i])
+		if r.maxAttempts > 0 && item.Attempts+1 >= r.maxAttempts {
+			slog.Error("outbox: giving up", "event_id", item.EventID, "topic", item.Topic, "attempts", item.Attempts+1)
+			if err := r.storage.MarkOutboxDead(ctx, item.ID, errs[i].Error()); err != nil {
+				slog.Error("outbox: mark dead", "event_id", item.EventID, "error", err.Error())
+			}
+			continue
+		}
+		retryAt := time.Now().Add(r.retryDelay(item.Attempts))
+		if err := r.storage.MarkOutboxFailed(ctx, item.ID, errs[i].Error(), retryAt); err != nil {
+			slog.Error("outbox: mark failed", "event_id", item.EventID, "error", err.Error())
+		}
+	}
+
+	if len(sent) == 0 {
+		return
+	}
+	if err := r.storage.MarkOutboxSent(ctx, sent); err != nil {
+		slog.Error("outbox: mark sent", "error", err.Error())
+	}
+}
+
+func (r *Relay) reportFailure(ctx context.Context, item models.OutboxMessage, publishErr error) {
+	if r.reporter == nil || item.URLID == "" || item.Attempts > 0 {
+		return
+	}
+	err := r.reporter.RecordRunResult(ctx, models.RunResult{
+		URLID:      item.URLID,
+		EventID:    item.EventID,
+		Status:     models.RunStatusPublishFailed,
+		Error:      publishErr.Error(),
+		FinishedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		slog.Error("outbox: report failure", "event_id", item.EventID, "error", err.Error())
+	}
+}
+
+func (r *Relay) retryDelay(attempts int) time.Duration {
+	delay := r.retryBase
+	for i := 0; i < attempts && delay < r.retryMax; i++ {
+		delay *= 2
+	}
+	if delay > r.retryMax {
+		delay = r.retryMax
+	}
+	return delay
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	if _, err := r.storage.DeleteSentOutbox(ctx, r.retentionSec, r.batchSize); err != nil {
+		slog.Error("outbox: cleanup", "error", err.Error())
+	}
+}
@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/publisher"
+)
+
+// fakeStorage serves a fixed outbox backlog batch by batch.
+type fakeStorage struct {
+	pending []models.OutboxMessage
+	next    int
+	sent    int
+}
+
+func (f *fakeStorage) ClaimOutbox(_ context.Context, limit int, _ time.Duration) ([]models.OutboxMessage, error) {
+	end := min(f.next+limit, len(f.pending))
+	batch := f.pending[f.next:end]
+	f.next = end
+	return batch, nil
+}
+
+func (f *fakeStorage) MarkOutboxSent(_ context.Context, ids []int64) error {
+	f.sent += len(ids)
+	return nil
+}
+
+func (f *fakeStorage) MarkOutboxFailed(context.Context, int64, string, time.Time) error {
+	return nil
+}
+
+func (f *fakeStorage) MarkOutboxDead(context.Context, int64, string) error {
+	return nil
+}
+
+func (f *fakeStorage) DeleteSentOutbox(context.Context, int, int) (int64, error) {
+	return 0, nil
+}
+
+// discardPublisher acknowledges every message, like a writer that never fails.
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(context.Context, ...publisher.Message) error { return nil }
+
+func (discardPublisher) Close() error { return nil }
+
+// BenchmarkPublish100k measures relaying 100k outbox messages through a
+// publisher that acknowledges everything, without a database or broker.
+func BenchmarkPublish100k(b *testing.B) {
+	const backlog = 100000
+	pending := make([]models.OutboxMessage, backlog)
+	for i := range pending {
+		pending[i] = models.OutboxMessage{
+			ID:      int64(i + 1),
+			EventID: fmt.Sprintf("event-%d", i),
+			Topic:   "parse_requested",
+			Key:     []byte(fmt.Sprintf("url-%d", i)),
+			Payload: []byte(`{"event_id":"x","url":"https://shop.example/item"}`),
+		}
+	}
+	storage := &fakeStorage{pending: pending}
+	r := New(storage, discardPublisher{}, nil, time.Second, 500, time.Minute, time.Second, time.Minute, 0, 3600)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		storage.next, storage.sent = 0, 0
+		r.runOnce(context.Background())
+		if storage.sent != backlog {
+			b.Fatalf("sent %d messages, want %d", storage.sent, backlog)
+		}
+	}
+	b.ReportMetric(float64(backlog*b.N)/b.Elapsed().Seconds(), "msgs/s")
+}
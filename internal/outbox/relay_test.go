@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/publisher"
+)
+
+// recordingStorage remembers which messages the relay settled and how.
+type recordingStorage struct {
+	fakeStorage
+	sentIDs   []int64
+	failedIDs []int64
+	deadIDs   []int64
+}
+
+func (s *recordingStorage) MarkOutboxSent(_ context.Context, ids []int64) error {
+	s.sentIDs = append(s.sentIDs, ids...)
+	return nil
+}
+
+func (s *recordingStorage) MarkOutboxFailed(_ context.Context, id int64, _ string, _ time.Time) error {
+	s.failedIDs = append(s.failedIDs, id)
+	return nil
+}
+
+func (s *recordingStorage) MarkOutboxDead(_ context.Context, id int64, _ string) error {
+	s.deadIDs = append(s.deadIDs, id)
+	return nil
+}
+
+// partialPublisher fails the messages whose keys are listed in failKeys.
+type partialPublisher struct {
+	failKeys map[string]bool
+}
+
+func (p partialPublisher) Publish(_ context.Context, msgs ...publisher.Message) error {
+	errs := make(publisher.Errors, len(msgs))
+	failed := false
+	for i, msg := range msgs {
+		if p.failKeys[string(msg.Key)] {
+			errs[i] = errors.New("partition unavailable")
+			failed = true
+		}
+	}
+	if !failed {
+		return nil
+	}
+	return errs
+}
+
+func (partialPublisher) Close() error { return nil }
+
+func outboxBatch(n int, attempts map[int64]int) []models.OutboxMessage {
+	items := make([]models.OutboxMessage, n)
+	for i := range items {
+		id := int64(i + 1)
+		items[i] = models.OutboxMessage{ID: id, EventID: fmt.Sprintf("event-%d", id), Topic: "parse_requested", Key: []byte(fmt.Sprintf("key-%d", id)), Attempts: attempts[id]}
+	}
+	return items
+}
+
+func TestPublishSettlesEachMessageOfAPartialFailure(t *testing.T) {
+	storage := &recordingStorage{}
+	pub := partialPublisher{failKeys: map[string]bool{"key-2": true, "key-4": true}}
+	r := New(storage, pub, nil, time.Second, 10, time.Minute, time.Second, time.Minute, 3, 3600)
+
+	// key-4 is on its last attempt and goes to publish_failures.
+	r.publish(context.Background(), outboxBatch(5, map[int64]int{4: 2}))
+
+	if want := []int64{1, 3, 5}; !slices.Equal(storage.sentIDs, want) {
+		t.Fatalf("sent %v, want %v", storage.sentIDs, want)
+	}
+	if want := []int64{2}; !slices.Equal(storage.failedIDs, want) {
+		t.Fatalf("rescheduled %v, want %v", storage.failedIDs, want)
+	}
+	if want := []int64{4}; !slices.Equal(storage.deadIDs, want) {
+		t.Fatalf("dead-lettered %v, want %v", storage.deadIDs, want)
+	}
+}
+
+type failingPublisher struct{ err error }
+
+func (p failingPublisher) Publish(context.Context, ...publisher.Message) error { return p.err }
+
+func (failingPublisher) Close() error { return nil }
+
+func TestPublishReschedulesWholeBatchOnBatchError(t *testing.T) {
+	storage := &recordingStorage{}
+	r := New(storage, failingPublisher{err: errors.New("broker unreachable")}, nil, time.Second, 10, time.Minute, time.Second, time.Minute, 3, 3600)
+
+	r.publish(context.Background(), outboxBatch(3, nil))
+
+	if len(storage.sentIDs) != 0 {
+		t.Fatalf("sent %v after a batch-wide failure, want nothing", storage.sentIDs)
+	}
+	if want := []int64{1, 2, 3}; !slices.Equal(storage.failedIDs, want) {
+		t.Fatalf("rescheduled %v, want %v", storage.failedIDs, want)
+	}
+}
@@ -0,0 +1,142 @@
+package parseresults
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/kafka"
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+	"github.com/LehaAlexey/Users/internal/services/userservice"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type Service interface {
+	RecordRunResult(ctx context.Context, result models.RunResult) error
+}
+
+// Consumer reads parse_completed / parse_failed events and records the run
+// outcome on the matching user_urls row. Offsets are committed only after the
+// result is stored; transient failures are retried.
+type Consumer struct {
+	reader         kafka.Reader
+	service        Service
+	completedTopic string
+	failedTopic    string
+	retryDelay     time.Duration
+}
+
+func New(reader kafka.Reader, service Service, completedTopic string, failedTopic string) *Consumer {
+	return &Consumer{
+		reader:         reader,
+		service:        service,
+		completedTopic: completedTopic,
+		failedTopic:    failedTopic,
+		retryDelay:     time.Second,
+	}
+}
+
+func (c *Consumer) Run(ctx context.Context) error {
+	defer func() { _ = c.reader.Close() }()
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Error("parseresults: fetch", "error", err.Error())
+			if err := c.sleep(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := c.handle(ctx, msg); err != nil {
+			return err
+		}
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Error("parseresults: commit", "error", err.Error())
+		}
+	}
+}
+
+// handle returns an error only when ctx is done; anything that cannot be
+// stored is logged and skipped so a bad message never blocks the partition.
+func (c *Consumer) handle(ctx context.Context, msg kafkago.Message) error {
+	result, err := c.decode(msg)
+	if err != nil {
+		slog.Error("parseresults: decode", "topic", msg.Topic, "offset", msg.Offset, "error", err.Error())
+		return nil
+	}
+
+	for {
+		err := c.service.RecordRunResult(ctx, result)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, userservice.ErrNotFound) || errors.Is(err, userservice.ErrInvalidArgument) {
+			slog.Warn("parseresults: skip result", "url_id", result.URLID, "error", err.Error())
+			return nil
+		}
+		slog.Error("parseresults: record result", "url_id", result.URLID, "error", err.Error())
+
+		if err := c.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// sleep waits retryDelay before the next attempt so a broker or database
+// outage does not turn into a hot loop.
+func (c *Consumer) sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(c.retryDelay):
+		return nil
+	}
+}
+
+func (c *Consumer) decode(msg kafkago.Message) (models.RunResult, error) {
+	switch msg.Topic {
+	case c.completedTopic:
+		var ev events.ParseCompleted
+		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+			return models.RunResult{}, err
+		}
+		return newResult(ev.ProductID, ev.EventID, ev.CorrelationID, models.RunStatusSucceeded, "", ev.OccurredAt)
+	case c.failedTopic:
+		var ev events.ParseFailed
+		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+			return models.RunResult{}, err
+		}
+		return newResult(ev.ProductID, ev.EventID, ev.CorrelationID, models.RunStatusFailed, ev.Error, ev.OccurredAt)
+	default:
+		return models.RunResult{}, fmt.Errorf("unexpected topic %q", msg.Topic)
+	}
+}
+
+func newResult(urlID string, eventID string, correlationID string, status string, errMsg string, at time.Time) (models.RunResult, error) {
+	if urlID == "" {
+		return models.RunResult{}, fmt.Errorf("product_id is required")
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return models.RunResult{
+		URLID:         urlID,
+		EventID:       eventID,
+		CorrelationID: correlationID,
+		Status:        status,
+		Error:         errMsg,
+		FinishedAt:    at.UTC(),
+	}, nil
+}
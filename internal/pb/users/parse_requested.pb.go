@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: parse_requested.proto
+
+package users
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ParseRequested asks the parser to fetch a URL. Published with the headers
+// content-type: application/x-protobuf and schema-version: 1. Field numbers
+// are stable; new fields are added without bumping the schema version.
+type ParseRequested struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	CorrelationId  string                 `protobuf:"bytes,3,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	ProductId      string                 `protobuf:"bytes,5,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Url            string                 `protobuf:"bytes,6,opt,name=url,proto3" json:"url,omitempty"`
+	ScheduledAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	Priority       int32                  `protobuf:"varint,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	SchemaVersion  int32                  `protobuf:"varint,9,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ParseRequested) Reset() {
+	*x = ParseRequested{}
+	mi := &file_parse_requested_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParseRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseRequested) ProtoMessage() {}
+
+func (x *ParseRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_parse_requested_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseRequested.ProtoReflect.Descriptor instead.
+func (*ParseRequested) Descriptor() ([]byte, []int) {
+	return file_parse_requested_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ParseRequested) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ParseRequested) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *ParseRequested) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ParseRequested) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *ParseRequested) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ParseRequested) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ParseRequested) GetScheduledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledAt
+	}
+	return nil
+}
+
+func (x *ParseRequested) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *ParseRequested) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+var File_parse_requested_proto protoreflect.FileDescriptor
+
+const file_parse_requested_proto_rawDesc = "" +
+	"\n" +
+	"\x15parse_requested.proto\x12\x05users\x1a\x1fgoogle/protobuf/timestamp.proto\"\xeb\x02\n" +
+	"\x0eParseRequested\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12;\n" +
+	"\voccurred_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12%\n" +
+	"\x0ecorrelation_id\x18\x03 \x01(\tR\rcorrelationId\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x05 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03url\x18\x06 \x01(\tR\x03url\x12=\n" +
+	"\fscheduled_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vscheduledAt\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\x05R\bpriority\x12%\n" +
+	"\x0eschema_version\x18\t \x01(\x05R\rschemaVersionB5Z3github.com/LehaAlexey/Users/internal/pb/users;usersb\x06proto3"
+
+var (
+	file_parse_requested_proto_rawDescOnce sync.Once
+	file_parse_requested_proto_rawDescData []byte
+)
+
+func file_parse_requested_proto_rawDescGZIP() []byte {
+	file_parse_requested_proto_rawDescOnce.Do(func() {
+		file_parse_requested_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_parse_requested_proto_rawDesc), len(file_parse_requested_proto_rawDesc)))
+	})
+	return file_parse_requested_proto_rawDescData
+}
+
+var file_parse_requested_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_parse_requested_proto_goTypes = []any{
+	(*ParseRequested)(nil),        // 0: users.ParseRequested
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_parse_requested_proto_depIdxs = []int32{
+	1, // 0: users.ParseRequested.occurred_at:type_name -> google.protobuf.Timestamp
+	1, // 1: users.ParseRequested.scheduled_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_parse_requested_proto_init() }
+func file_parse_requested_proto_init() {
+	if File_parse_requested_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_parse_requested_proto_rawDesc), len(file_parse_requested_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_parse_requested_proto_goTypes,
+		DependencyIndexes: file_parse_requested_proto_depIdxs,
+		MessageInfos:      file_parse_requested_proto_msgTypes,
+	}.Build()
+	File_parse_requested_proto = out.File
+	file_parse_requested_proto_goTypes = nil
+	file_parse_requested_proto_depIdxs = nil
+}
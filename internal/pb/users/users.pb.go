@@ -97,6 +97,17 @@ type UserURL struct {
 	NormalizedUrl          string                 `protobuf:"bytes,4,opt,name=normalized_url,json=normalizedUrl,proto3" json:"normalized_url,omitempty"`
 	PollingIntervalSeconds int32                  `protobuf:"varint,5,opt,name=polling_interval_seconds,json=pollingIntervalSeconds,proto3" json:"polling_interval_seconds,omitempty"`
 	CreatedAt              int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Paused                 bool                   `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+	NextRunAt              int64                  `protobuf:"varint,8,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt              int64                  `protobuf:"varint,9,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	LastStatus             string                 `protobuf:"bytes,10,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
+	LastError              string                 `protobuf:"bytes,11,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	ConsecutiveFailures    int32                  `protobuf:"varint,12,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	DisabledAt             int64                  `protobuf:"varint,13,opt,name=disabled_at,json=disabledAt,proto3" json:"disabled_at,omitempty"`
+	DisabledReason         string                 `protobuf:"bytes,14,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"`
+	CronExpr               string                 `protobuf:"bytes,15,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	Timezone               string                 `protobuf:"bytes,16,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Priority               int32                  `protobuf:"varint,17,opt,name=priority,proto3" json:"priority,omitempty"`
 	unknownFields          protoimpl.UnknownFields
 	sizeCache              protoimpl.SizeCache
 }
@@ -173,6 +184,83 @@ func (x *UserURL) GetCreatedAt() int64 {
 	return 0
 }
 
+func (x *UserURL) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *UserURL) GetNextRunAt() int64 {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return 0
+}
+
+func (x *UserURL) GetLastRunAt() int64 {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return 0
+}
+
+func (x *UserURL) GetLastStatus() string {
+	if x != nil {
+		return x.LastStatus
+	}
+	return ""
+}
+
+func (x *UserURL) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *UserURL) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *UserURL) GetDisabledAt() int64 {
+	if x != nil {
+		return x.DisabledAt
+	}
+	return 0
+}
+
+func (x *UserURL) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+func (x *UserURL) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
+func (x *UserURL) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *UserURL) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
 type CreateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -357,29 +445,28 @@ func (x *GetUserResponse) GetUser() *User {
 	return nil
 }
 
-type AddUrlRequest struct {
-	state                  protoimpl.MessageState `protogen:"open.v1"`
-	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Url                    string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	PollingIntervalSeconds int32                  `protobuf:"varint,3,opt,name=polling_interval_seconds,json=pollingIntervalSeconds,proto3" json:"polling_interval_seconds,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+// Matches the email case-insensitively.
+type GetUserByEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddUrlRequest) Reset() {
-	*x = AddUrlRequest{}
+func (x *GetUserByEmailRequest) Reset() {
+	*x = GetUserByEmailRequest{}
 	mi := &file_users_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddUrlRequest) String() string {
+func (x *GetUserByEmailRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddUrlRequest) ProtoMessage() {}
+func (*GetUserByEmailRequest) ProtoMessage() {}
 
-func (x *AddUrlRequest) ProtoReflect() protoreflect.Message {
+func (x *GetUserByEmailRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,53 +478,39 @@ func (x *AddUrlRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddUrlRequest.ProtoReflect.Descriptor instead.
-func (*AddUrlRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserByEmailRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailRequest) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *AddUrlRequest) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
-}
-
-func (x *AddUrlRequest) GetUrl() string {
+func (x *GetUserByEmailRequest) GetEmail() string {
 	if x != nil {
-		return x.Url
+		return x.Email
 	}
 	return ""
 }
 
-func (x *AddUrlRequest) GetPollingIntervalSeconds() int32 {
-	if x != nil {
-		return x.PollingIntervalSeconds
-	}
-	return 0
-}
-
-type AddUrlResponse struct {
+type GetUserByEmailResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           *UserURL               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddUrlResponse) Reset() {
-	*x = AddUrlResponse{}
+func (x *GetUserByEmailResponse) Reset() {
+	*x = GetUserByEmailResponse{}
 	mi := &file_users_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddUrlResponse) String() string {
+func (x *GetUserByEmailResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddUrlResponse) ProtoMessage() {}
+func (*GetUserByEmailResponse) ProtoMessage() {}
 
-func (x *AddUrlResponse) ProtoReflect() protoreflect.Message {
+func (x *GetUserByEmailResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -449,40 +522,46 @@ func (x *AddUrlResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddUrlResponse.ProtoReflect.Descriptor instead.
-func (*AddUrlResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserByEmailResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByEmailResponse) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *AddUrlResponse) GetUrl() *UserURL {
+func (x *GetUserByEmailResponse) GetUser() *User {
 	if x != nil {
-		return x.Url
+		return x.User
 	}
 	return nil
 }
 
-type ListUrlsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+type ListUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token of the previous response; the filters must match.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Case-insensitive email prefix or name substring.
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// Unix seconds; created_from is inclusive, created_to exclusive.
+	CreatedFrom   int64 `protobuf:"varint,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     int64 `protobuf:"varint,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUrlsRequest) Reset() {
-	*x = ListUrlsRequest{}
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
 	mi := &file_users_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUrlsRequest) String() string {
+func (x *ListUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUrlsRequest) ProtoMessage() {}
+func (*ListUsersRequest) ProtoMessage() {}
 
-func (x *ListUrlsRequest) ProtoReflect() protoreflect.Message {
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -494,46 +573,68 @@ func (x *ListUrlsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUrlsRequest.ProtoReflect.Descriptor instead.
-func (*ListUrlsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListUrlsRequest) GetUserId() string {
+func (x *ListUsersRequest) GetLimit() int32 {
 	if x != nil {
-		return x.UserId
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *ListUrlsRequest) GetLimit() int32 {
+func (x *ListUsersRequest) GetQuery() string {
 	if x != nil {
-		return x.Limit
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
 	}
 	return 0
 }
 
-type ListUrlsResponse struct {
+func (x *ListUsersRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+type UserSummary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Urls          []*UserURL             `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UrlCount      int64                  `protobuf:"varint,2,opt,name=url_count,json=urlCount,proto3" json:"url_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUrlsResponse) Reset() {
-	*x = ListUrlsResponse{}
+func (x *UserSummary) Reset() {
+	*x = UserSummary{}
 	mi := &file_users_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUrlsResponse) String() string {
+func (x *UserSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUrlsResponse) ProtoMessage() {}
+func (*UserSummary) ProtoMessage() {}
 
-func (x *ListUrlsResponse) ProtoReflect() protoreflect.Message {
+func (x *UserSummary) ProtoReflect() protoreflect.Message {
 	mi := &file_users_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -545,63 +646,1837 @@ func (x *ListUrlsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUrlsResponse.ProtoReflect.Descriptor instead.
-func (*ListUrlsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserSummary.ProtoReflect.Descriptor instead.
+func (*UserSummary) Descriptor() ([]byte, []int) {
 	return file_users_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListUrlsResponse) GetUrls() []*UserURL {
+func (x *UserSummary) GetUser() *User {
 	if x != nil {
-		return x.Urls
+		return x.User
 	}
 	return nil
 }
 
-var File_users_proto protoreflect.FileDescriptor
+func (x *UserSummary) GetUrlCount() int64 {
+	if x != nil {
+		return x.UrlCount
+	}
+	return 0
+}
 
-const file_users_proto_rawDesc = "" +
-	"\n" +
-	"\vusers.proto\x12\x05users\"_\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"\xc4\x01\n" +
-	"\aUserURL\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03url\x18\x03 \x01(\tR\x03url\x12%\n" +
-	"\x0enormalized_url\x18\x04 \x01(\tR\rnormalizedUrl\x128\n" +
-	"\x18polling_interval_seconds\x18\x05 \x01(\x05R\x16pollingIntervalSeconds\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"=\n" +
-	"\x11CreateUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"5\n" +
-	"\x12CreateUserResponse\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\" \n" +
-	"\x0eGetUserRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
-	"\x0fGetUserResponse\x12\x1f\n" +
-	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"t\n" +
-	"\rAddUrlRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03url\x128\n" +
-	"\x18polling_interval_seconds\x18\x03 \x01(\x05R\x16pollingIntervalSeconds\"2\n" +
-	"\x0eAddUrlResponse\x12 \n" +
-	"\x03url\x18\x01 \x01(\v2\x0e.users.UserURLR\x03url\"@\n" +
-	"\x0fListUrlsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"6\n" +
-	"\x10ListUrlsResponse\x12\"\n" +
-	"\x04urls\x18\x01 \x03(\v2\x0e.users.UserURLR\x04urls2\xff\x01\n" +
-	"\fUsersService\x12A\n" +
-	"\n" +
-	"CreateUser\x12\x18.users.CreateUserRequest\x1a\x19.users.CreateUserResponse\x128\n" +
-	"\aGetUser\x12\x15.users.GetUserRequest\x1a\x16.users.GetUserResponse\x125\n" +
+type ListUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*UserSummary         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_users_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserSummary {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         *string                `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Name          *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_users_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_users_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_users_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_users_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{14}
+}
+
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_users_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_users_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestoreUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type AddUrlRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url                    string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	PollingIntervalSeconds int32                  `protobuf:"varint,3,opt,name=polling_interval_seconds,json=pollingIntervalSeconds,proto3" json:"polling_interval_seconds,omitempty"`
+	// Optional five-field cron expression; overrides polling_interval_seconds.
+	CronExpr string `protobuf:"bytes,4,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	// IANA timezone the cron expression is evaluated in, UTC by default.
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// 0 (normal) to 9; higher priority URLs are scheduled first.
+	Priority      int32 `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUrlRequest) Reset() {
+	*x = AddUrlRequest{}
+	mi := &file_users_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUrlRequest) ProtoMessage() {}
+
+func (x *AddUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUrlRequest.ProtoReflect.Descriptor instead.
+func (*AddUrlRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AddUrlRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddUrlRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AddUrlRequest) GetPollingIntervalSeconds() int32 {
+	if x != nil {
+		return x.PollingIntervalSeconds
+	}
+	return 0
+}
+
+func (x *AddUrlRequest) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
+func (x *AddUrlRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *AddUrlRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type AddUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *UserURL               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddUrlResponse) Reset() {
+	*x = AddUrlResponse{}
+	mi := &file_users_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUrlResponse) ProtoMessage() {}
+
+func (x *AddUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUrlResponse.ProtoReflect.Descriptor instead.
+func (*AddUrlResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddUrlResponse) GetUrl() *UserURL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type ListUrlsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token of the previous response; the other filters must match.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Host      string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	// active, paused (by the owner) or disabled (automatically, after repeated
+	// failures); each URL matches exactly one of them.
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// Case-insensitive substring of the URL.
+	Search string `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
+	// created_at, next_run_at or url; a leading "-" sorts descending.
+	// Defaults to -created_at.
+	Sort          string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlsRequest) Reset() {
+	*x = ListUrlsRequest{}
+	mi := &file_users_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlsRequest) ProtoMessage() {}
+
+func (x *ListUrlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlsRequest.ProtoReflect.Descriptor instead.
+func (*ListUrlsRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListUrlsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUrlsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUrlsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUrlsRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ListUrlsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListUrlsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListUrlsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListUrlsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Urls  []*UserURL             `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUrlsResponse) Reset() {
+	*x = ListUrlsResponse{}
+	mi := &file_users_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUrlsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUrlsResponse) ProtoMessage() {}
+
+func (x *ListUrlsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUrlsResponse.ProtoReflect.Descriptor instead.
+func (*ListUrlsResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListUrlsResponse) GetUrls() []*UserURL {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ListUrlsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateUrlRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UrlId  string                 `protobuf:"bytes,2,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	// Zero keeps the current schedule.
+	PollingIntervalSeconds int32 `protobuf:"varint,3,opt,name=polling_interval_seconds,json=pollingIntervalSeconds,proto3" json:"polling_interval_seconds,omitempty"`
+	// Unset keeps the current priority.
+	Priority      *int32 `protobuf:"varint,4,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUrlRequest) Reset() {
+	*x = UpdateUrlRequest{}
+	mi := &file_users_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUrlRequest) ProtoMessage() {}
+
+func (x *UpdateUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUrlRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUrlRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateUrlRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUrlRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+func (x *UpdateUrlRequest) GetPollingIntervalSeconds() int32 {
+	if x != nil {
+		return x.PollingIntervalSeconds
+	}
+	return 0
+}
+
+func (x *UpdateUrlRequest) GetPriority() int32 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+type UpdateUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *UserURL               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUrlResponse) Reset() {
+	*x = UpdateUrlResponse{}
+	mi := &file_users_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUrlResponse) ProtoMessage() {}
+
+func (x *UpdateUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUrlResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUrlResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateUrlResponse) GetUrl() *UserURL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type PauseUrlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UrlId         string                 `protobuf:"bytes,2,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseUrlRequest) Reset() {
+	*x = PauseUrlRequest{}
+	mi := &file_users_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseUrlRequest) ProtoMessage() {}
+
+func (x *PauseUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseUrlRequest.ProtoReflect.Descriptor instead.
+func (*PauseUrlRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PauseUrlRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PauseUrlRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+type PauseUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *UserURL               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseUrlResponse) Reset() {
+	*x = PauseUrlResponse{}
+	mi := &file_users_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseUrlResponse) ProtoMessage() {}
+
+func (x *PauseUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseUrlResponse.ProtoReflect.Descriptor instead.
+func (*PauseUrlResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PauseUrlResponse) GetUrl() *UserURL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type ResumeUrlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UrlId         string                 `protobuf:"bytes,2,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeUrlRequest) Reset() {
+	*x = ResumeUrlRequest{}
+	mi := &file_users_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeUrlRequest) ProtoMessage() {}
+
+func (x *ResumeUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeUrlRequest.ProtoReflect.Descriptor instead.
+func (*ResumeUrlRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResumeUrlRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ResumeUrlRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+type ResumeUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           *UserURL               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeUrlResponse) Reset() {
+	*x = ResumeUrlResponse{}
+	mi := &file_users_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeUrlResponse) ProtoMessage() {}
+
+func (x *ResumeUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeUrlResponse.ProtoReflect.Descriptor instead.
+func (*ResumeUrlResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ResumeUrlResponse) GetUrl() *UserURL {
+	if x != nil {
+		return x.Url
+	}
+	return nil
+}
+
+type DeleteUrlRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UrlId         string                 `protobuf:"bytes,2,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUrlRequest) Reset() {
+	*x = DeleteUrlRequest{}
+	mi := &file_users_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUrlRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUrlRequest) ProtoMessage() {}
+
+func (x *DeleteUrlRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUrlRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUrlRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteUrlRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteUrlRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+type DeleteUrlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUrlResponse) Reset() {
+	*x = DeleteUrlResponse{}
+	mi := &file_users_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUrlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUrlResponse) ProtoMessage() {}
+
+func (x *DeleteUrlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUrlResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUrlResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{28}
+}
+
+type TriggerParseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UrlId         string                 `protobuf:"bytes,2,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerParseRequest) Reset() {
+	*x = TriggerParseRequest{}
+	mi := &file_users_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerParseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerParseRequest) ProtoMessage() {}
+
+func (x *TriggerParseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerParseRequest.ProtoReflect.Descriptor instead.
+func (*TriggerParseRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TriggerParseRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TriggerParseRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+type TriggerParseResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UrlId   string                 `protobuf:"bytes,1,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	EventId string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Carried by the ParseRequested event and the parse result.
+	CorrelationId string `protobuf:"bytes,3,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	RequestedAt   int64  `protobuf:"varint,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerParseResponse) Reset() {
+	*x = TriggerParseResponse{}
+	mi := &file_users_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerParseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerParseResponse) ProtoMessage() {}
+
+func (x *TriggerParseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerParseResponse.ProtoReflect.Descriptor instead.
+func (*TriggerParseResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TriggerParseResponse) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+func (x *TriggerParseResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *TriggerParseResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *TriggerParseResponse) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+type ReportParseResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UrlId         string                 `protobuf:"bytes,1,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CorrelationId string                 `protobuf:"bytes,3,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	FinishedAt    int64                  `protobuf:"varint,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportParseResultRequest) Reset() {
+	*x = ReportParseResultRequest{}
+	mi := &file_users_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportParseResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportParseResultRequest) ProtoMessage() {}
+
+func (x *ReportParseResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportParseResultRequest.ProtoReflect.Descriptor instead.
+func (*ReportParseResultRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReportParseResultRequest) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+func (x *ReportParseResultRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ReportParseResultRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ReportParseResultRequest) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReportParseResultRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReportParseResultRequest) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type ReportParseResultResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportParseResultResponse) Reset() {
+	*x = ReportParseResultResponse{}
+	mi := &file_users_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportParseResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportParseResultResponse) ProtoMessage() {}
+
+func (x *ReportParseResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportParseResultResponse.ProtoReflect.Descriptor instead.
+func (*ReportParseResultResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{32}
+}
+
+// PublishFailure is an outbox message that exhausted its publish attempts.
+type PublishFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId       string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	UrlId         string                 `protobuf:"bytes,3,opt,name=url_id,json=urlId,proto3" json:"url_id,omitempty"`
+	Topic         string                 `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Key           string                 `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,7,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Attempts      int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FailedAt      int64                  `protobuf:"varint,11,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishFailure) Reset() {
+	*x = PublishFailure{}
+	mi := &file_users_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishFailure) ProtoMessage() {}
+
+func (x *PublishFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishFailure.ProtoReflect.Descriptor instead.
+func (*PublishFailure) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PublishFailure) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PublishFailure) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *PublishFailure) GetUrlId() string {
+	if x != nil {
+		return x.UrlId
+	}
+	return ""
+}
+
+func (x *PublishFailure) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *PublishFailure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PublishFailure) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PublishFailure) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *PublishFailure) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *PublishFailure) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *PublishFailure) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PublishFailure) GetFailedAt() int64 {
+	if x != nil {
+		return x.FailedAt
+	}
+	return 0
+}
+
+type ListPublishFailuresRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Last id of the previous page; 0 starts from the oldest failure.
+	AfterId       int64 `protobuf:"varint,2,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishFailuresRequest) Reset() {
+	*x = ListPublishFailuresRequest{}
+	mi := &file_users_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishFailuresRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishFailuresRequest) ProtoMessage() {}
+
+func (x *ListPublishFailuresRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishFailuresRequest.ProtoReflect.Descriptor instead.
+func (*ListPublishFailuresRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListPublishFailuresRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPublishFailuresRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+type ListPublishFailuresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Failures      []*PublishFailure      `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublishFailuresResponse) Reset() {
+	*x = ListPublishFailuresResponse{}
+	mi := &file_users_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublishFailuresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublishFailuresResponse) ProtoMessage() {}
+
+func (x *ListPublishFailuresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublishFailuresResponse.ProtoReflect.Descriptor instead.
+func (*ListPublishFailuresResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListPublishFailuresResponse) GetFailures() []*PublishFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type ReplayPublishFailureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayPublishFailureRequest) Reset() {
+	*x = ReplayPublishFailureRequest{}
+	mi := &file_users_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayPublishFailureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayPublishFailureRequest) ProtoMessage() {}
+
+func (x *ReplayPublishFailureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayPublishFailureRequest.ProtoReflect.Descriptor instead.
+func (*ReplayPublishFailureRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ReplayPublishFailureRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ReplayPublishFailureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayPublishFailureResponse) Reset() {
+	*x = ReplayPublishFailureResponse{}
+	mi := &file_users_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayPublishFailureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayPublishFailureResponse) ProtoMessage() {}
+
+func (x *ReplayPublishFailureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayPublishFailureResponse.ProtoReflect.Descriptor instead.
+func (*ReplayPublishFailureResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{37}
+}
+
+type DiscardPublishFailureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardPublishFailureRequest) Reset() {
+	*x = DiscardPublishFailureRequest{}
+	mi := &file_users_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardPublishFailureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardPublishFailureRequest) ProtoMessage() {}
+
+func (x *DiscardPublishFailureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardPublishFailureRequest.ProtoReflect.Descriptor instead.
+func (*DiscardPublishFailureRequest) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DiscardPublishFailureRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DiscardPublishFailureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardPublishFailureResponse) Reset() {
+	*x = DiscardPublishFailureResponse{}
+	mi := &file_users_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardPublishFailureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardPublishFailureResponse) ProtoMessage() {}
+
+func (x *DiscardPublishFailureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_users_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardPublishFailureResponse.ProtoReflect.Descriptor instead.
+func (*DiscardPublishFailureResponse) Descriptor() ([]byte, []int) {
+	return file_users_proto_rawDescGZIP(), []int{39}
+}
+
+var File_users_proto protoreflect.FileDescriptor
+
+const file_users_proto_rawDesc = "" +
+	"\n" +
+	"\vusers.proto\x12\x05users\"_\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"\xae\x04\n" +
+	"\aUserURL\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12%\n" +
+	"\x0enormalized_url\x18\x04 \x01(\tR\rnormalizedUrl\x128\n" +
+	"\x18polling_interval_seconds\x18\x05 \x01(\x05R\x16pollingIntervalSeconds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x16\n" +
+	"\x06paused\x18\a \x01(\bR\x06paused\x12\x1e\n" +
+	"\vnext_run_at\x18\b \x01(\x03R\tnextRunAt\x12\x1e\n" +
+	"\vlast_run_at\x18\t \x01(\x03R\tlastRunAt\x12\x1f\n" +
+	"\vlast_status\x18\n" +
+	" \x01(\tR\n" +
+	"lastStatus\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\v \x01(\tR\tlastError\x121\n" +
+	"\x14consecutive_failures\x18\f \x01(\x05R\x13consecutiveFailures\x12\x1f\n" +
+	"\vdisabled_at\x18\r \x01(\x03R\n" +
+	"disabledAt\x12'\n" +
+	"\x0fdisabled_reason\x18\x0e \x01(\tR\x0edisabledReason\x12\x1b\n" +
+	"\tcron_expr\x18\x0f \x01(\tR\bcronExpr\x12\x1a\n" +
+	"\btimezone\x18\x10 \x01(\tR\btimezone\x12\x1a\n" +
+	"\bpriority\x18\x11 \x01(\x05R\bpriority\"=\n" +
+	"\x11CreateUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"5\n" +
+	"\x12CreateUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x0fGetUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"-\n" +
+	"\x15GetUserByEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"9\n" +
+	"\x16GetUserByEmailResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"\x9f\x01\n" +
+	"\x10ListUsersRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12!\n" +
+	"\fcreated_from\x18\x04 \x01(\x03R\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\x03R\tcreatedTo\"K\n" +
+	"\vUserSummary\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\x12\x1b\n" +
+	"\turl_count\x18\x02 \x01(\x03R\burlCount\"e\n" +
+	"\x11ListUsersResponse\x12(\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.users.UserSummaryR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"j\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01B\b\n" +
+	"\x06_emailB\a\n" +
+	"\x05_name\"5\n" +
+	"\x12UpdateUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x14\n" +
+	"\x12DeleteUserResponse\"$\n" +
+	"\x12RestoreUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"6\n" +
+	"\x13RestoreUserResponse\x12\x1f\n" +
+	"\x04user\x18\x01 \x01(\v2\v.users.UserR\x04user\"\xc9\x01\n" +
+	"\rAddUrlRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x128\n" +
+	"\x18polling_interval_seconds\x18\x03 \x01(\x05R\x16pollingIntervalSeconds\x12\x1b\n" +
+	"\tcron_expr\x18\x04 \x01(\tR\bcronExpr\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\"2\n" +
+	"\x0eAddUrlResponse\x12 \n" +
+	"\x03url\x18\x01 \x01(\v2\x0e.users.UserURLR\x03url\"\xb7\x01\n" +
+	"\x0fListUrlsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04host\x18\x04 \x01(\tR\x04host\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\"^\n" +
+	"\x10ListUrlsResponse\x12\"\n" +
+	"\x04urls\x18\x01 \x03(\v2\x0e.users.UserURLR\x04urls\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xaa\x01\n" +
+	"\x10UpdateUrlRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06url_id\x18\x02 \x01(\tR\x05urlId\x128\n" +
+	"\x18polling_interval_seconds\x18\x03 \x01(\x05R\x16pollingIntervalSeconds\x12\x1f\n" +
+	"\bpriority\x18\x04 \x01(\x05H\x00R\bpriority\x88\x01\x01B\v\n" +
+	"\t_priority\"5\n" +
+	"\x11UpdateUrlResponse\x12 \n" +
+	"\x03url\x18\x01 \x01(\v2\x0e.users.UserURLR\x03url\"A\n" +
+	"\x0fPauseUrlRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06url_id\x18\x02 \x01(\tR\x05urlId\"4\n" +
+	"\x10PauseUrlResponse\x12 \n" +
+	"\x03url\x18\x01 \x01(\v2\x0e.users.UserURLR\x03url\"B\n" +
+	"\x10ResumeUrlRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06url_id\x18\x02 \x01(\tR\x05urlId\"5\n" +
+	"\x11ResumeUrlResponse\x12 \n" +
+	"\x03url\x18\x01 \x01(\v2\x0e.users.UserURLR\x03url\"B\n" +
+	"\x10DeleteUrlRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06url_id\x18\x02 \x01(\tR\x05urlId\"\x13\n" +
+	"\x11DeleteUrlResponse\"E\n" +
+	"\x13TriggerParseRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06url_id\x18\x02 \x01(\tR\x05urlId\"\x92\x01\n" +
+	"\x14TriggerParseResponse\x12\x15\n" +
+	"\x06url_id\x18\x01 \x01(\tR\x05urlId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12%\n" +
+	"\x0ecorrelation_id\x18\x03 \x01(\tR\rcorrelationId\x12!\n" +
+	"\frequested_at\x18\x04 \x01(\x03R\vrequestedAt\"\xc4\x01\n" +
+	"\x18ReportParseResultRequest\x12\x15\n" +
+	"\x06url_id\x18\x01 \x01(\tR\x05urlId\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12%\n" +
+	"\x0ecorrelation_id\x18\x03 \x01(\tR\rcorrelationId\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\x03R\n" +
+	"finishedAt\"\x1b\n" +
+	"\x19ReportParseResultResponse\"\x85\x03\n" +
+	"\x0ePublishFailure\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x15\n" +
+	"\x06url_id\x18\x03 \x01(\tR\x05urlId\x12\x14\n" +
+	"\x05topic\x18\x04 \x01(\tR\x05topic\x12\x10\n" +
+	"\x03key\x18\x05 \x01(\tR\x03key\x12\x18\n" +
+	"\apayload\x18\x06 \x01(\fR\apayload\x12<\n" +
+	"\aheaders\x18\a \x03(\v2\".users.PublishFailure.HeadersEntryR\aheaders\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\tfailed_at\x18\v \x01(\x03R\bfailedAt\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\x1aListPublishFailuresRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x19\n" +
+	"\bafter_id\x18\x02 \x01(\x03R\aafterId\"P\n" +
+	"\x1bListPublishFailuresResponse\x121\n" +
+	"\bfailures\x18\x01 \x03(\v2\x15.users.PublishFailureR\bfailures\"-\n" +
+	"\x1bReplayPublishFailureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1e\n" +
+	"\x1cReplayPublishFailureResponse\".\n" +
+	"\x1cDiscardPublishFailureRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x1f\n" +
+	"\x1dDiscardPublishFailureResponse2\x9b\n" +
+	"\n" +
+	"\fUsersService\x12A\n" +
+	"\n" +
+	"CreateUser\x12\x18.users.CreateUserRequest\x1a\x19.users.CreateUserResponse\x128\n" +
+	"\aGetUser\x12\x15.users.GetUserRequest\x1a\x16.users.GetUserResponse\x12M\n" +
+	"\x0eGetUserByEmail\x12\x1c.users.GetUserByEmailRequest\x1a\x1d.users.GetUserByEmailResponse\x12>\n" +
+	"\tListUsers\x12\x17.users.ListUsersRequest\x1a\x18.users.ListUsersResponse\x12A\n" +
+	"\n" +
+	"UpdateUser\x12\x18.users.UpdateUserRequest\x1a\x19.users.UpdateUserResponse\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x18.users.DeleteUserRequest\x1a\x19.users.DeleteUserResponse\x12D\n" +
+	"\vRestoreUser\x12\x19.users.RestoreUserRequest\x1a\x1a.users.RestoreUserResponse\x125\n" +
 	"\x06AddUrl\x12\x14.users.AddUrlRequest\x1a\x15.users.AddUrlResponse\x12;\n" +
-	"\bListUrls\x12\x16.users.ListUrlsRequest\x1a\x17.users.ListUrlsResponseB5Z3github.com/LehaAlexey/Users/internal/pb/users;usersb\x06proto3"
+	"\bListUrls\x12\x16.users.ListUrlsRequest\x1a\x17.users.ListUrlsResponse\x12>\n" +
+	"\tUpdateUrl\x12\x17.users.UpdateUrlRequest\x1a\x18.users.UpdateUrlResponse\x12;\n" +
+	"\bPauseUrl\x12\x16.users.PauseUrlRequest\x1a\x17.users.PauseUrlResponse\x12>\n" +
+	"\tResumeUrl\x12\x17.users.ResumeUrlRequest\x1a\x18.users.ResumeUrlResponse\x12>\n" +
+	"\tDeleteUrl\x12\x17.users.DeleteUrlRequest\x1a\x18.users.DeleteUrlResponse\x12G\n" +
+	"\fTriggerParse\x12\x1a.users.TriggerParseRequest\x1a\x1b.users.TriggerParseResponse\x12V\n" +
+	"\x11ReportParseResult\x12\x1f.users.ReportParseResultRequest\x1a .users.ReportParseResultResponse\x12\\\n" +
+	"\x13ListPublishFailures\x12!.users.ListPublishFailuresRequest\x1a\".users.ListPublishFailuresResponse\x12_\n" +
+	"\x14ReplayPublishFailure\x12\".users.ReplayPublishFailureRequest\x1a#.users.ReplayPublishFailureResponse\x12b\n" +
+	"\x15DiscardPublishFailure\x12#.users.DiscardPublishFailureRequest\x1a$.users.DiscardPublishFailureResponseB5Z3github.com/LehaAlexey/Users/internal/pb/users;usersb\x06proto3"
 
 var (
 	file_users_proto_rawDescOnce sync.Once
@@ -615,37 +2490,106 @@ func file_users_proto_rawDescGZIP() []byte {
 	return file_users_proto_rawDescData
 }
 
-var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_users_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_users_proto_goTypes = []any{
-	(*User)(nil),               // 0: users.User
-	(*UserURL)(nil),            // 1: users.UserURL
-	(*CreateUserRequest)(nil),  // 2: users.CreateUserRequest
-	(*CreateUserResponse)(nil), // 3: users.CreateUserResponse
-	(*GetUserRequest)(nil),     // 4: users.GetUserRequest
-	(*GetUserResponse)(nil),    // 5: users.GetUserResponse
-	(*AddUrlRequest)(nil),      // 6: users.AddUrlRequest
-	(*AddUrlResponse)(nil),     // 7: users.AddUrlResponse
-	(*ListUrlsRequest)(nil),    // 8: users.ListUrlsRequest
-	(*ListUrlsResponse)(nil),   // 9: users.ListUrlsResponse
+	(*User)(nil),                          // 0: users.User
+	(*UserURL)(nil),                       // 1: users.UserURL
+	(*CreateUserRequest)(nil),             // 2: users.CreateUserRequest
+	(*CreateUserResponse)(nil),            // 3: users.CreateUserResponse
+	(*GetUserRequest)(nil),                // 4: users.GetUserRequest
+	(*GetUserResponse)(nil),               // 5: users.GetUserResponse
+	(*GetUserByEmailRequest)(nil),         // 6: users.GetUserByEmailRequest
+	(*GetUserByEmailResponse)(nil),        // 7: users.GetUserByEmailResponse
+	(*ListUsersRequest)(nil),              // 8: users.ListUsersRequest
+	(*UserSummary)(nil),                   // 9: users.UserSummary
+	(*ListUsersResponse)(nil),             // 10: users.ListUsersResponse
+	(*UpdateUserRequest)(nil),             // 11: users.UpdateUserRequest
+	(*UpdateUserResponse)(nil),            // 12: users.UpdateUserResponse
+	(*DeleteUserRequest)(nil),             // 13: users.DeleteUserRequest
+	(*DeleteUserResponse)(nil),            // 14: users.DeleteUserResponse
+	(*RestoreUserRequest)(nil),            // 15: users.RestoreUserRequest
+	(*RestoreUserResponse)(nil),           // 16: users.RestoreUserResponse
+	(*AddUrlRequest)(nil),                 // 17: users.AddUrlRequest
+	(*AddUrlResponse)(nil),                // 18: users.AddUrlResponse
+	(*ListUrlsRequest)(nil),               // 19: users.ListUrlsRequest
+	(*ListUrlsResponse)(nil),              // 20: users.ListUrlsResponse
+	(*UpdateUrlRequest)(nil),              // 21: users.UpdateUrlRequest
+	(*UpdateUrlResponse)(nil),             // 22: users.UpdateUrlResponse
+	(*PauseUrlRequest)(nil),               // 23: users.PauseUrlRequest
+	(*PauseUrlResponse)(nil),              // 24: users.PauseUrlResponse
+	(*ResumeUrlRequest)(nil),              // 25: users.ResumeUrlRequest
+	(*ResumeUrlResponse)(nil),             // 26: users.ResumeUrlResponse
+	(*DeleteUrlRequest)(nil),              // 27: users.DeleteUrlRequest
+	(*DeleteUrlResponse)(nil),             // 28: users.DeleteUrlResponse
+	(*TriggerParseRequest)(nil),           // 29: users.TriggerParseRequest
+	(*TriggerParseResponse)(nil),          // 30: users.TriggerParseResponse
+	(*ReportParseResultRequest)(nil),      // 31: users.ReportParseResultRequest
+	(*ReportParseResultResponse)(nil),     // 32: users.ReportParseResultResponse
+	(*PublishFailure)(nil),                // 33: users.PublishFailure
+	(*ListPublishFailuresRequest)(nil),    // 34: users.ListPublishFailuresRequest
+	(*ListPublishFailuresResponse)(nil),   // 35: users.ListPublishFailuresResponse
+	(*ReplayPublishFailureRequest)(nil),   // 36: users.ReplayPublishFailureRequest
+	(*ReplayPublishFailureResponse)(nil),  // 37: users.ReplayPublishFailureResponse
+	(*DiscardPublishFailureRequest)(nil),  // 38: users.DiscardPublishFailureRequest
+	(*DiscardPublishFailureResponse)(nil), // 39: users.DiscardPublishFailureResponse
+	nil,                                   // 40: users.PublishFailure.HeadersEntry
 }
 var file_users_proto_depIdxs = []int32{
-	0, // 0: users.CreateUserResponse.user:type_name -> users.User
-	0, // 1: users.GetUserResponse.user:type_name -> users.User
-	1, // 2: users.AddUrlResponse.url:type_name -> users.UserURL
-	1, // 3: users.ListUrlsResponse.urls:type_name -> users.UserURL
-	2, // 4: users.UsersService.CreateUser:input_type -> users.CreateUserRequest
-	4, // 5: users.UsersService.GetUser:input_type -> users.GetUserRequest
-	6, // 6: users.UsersService.AddUrl:input_type -> users.AddUrlRequest
-	8, // 7: users.UsersService.ListUrls:input_type -> users.ListUrlsRequest
-	3, // 8: users.UsersService.CreateUser:output_type -> users.CreateUserResponse
-	5, // 9: users.UsersService.GetUser:output_type -> users.GetUserResponse
-	7, // 10: users.UsersService.AddUrl:output_type -> users.AddUrlResponse
-	9, // 11: users.UsersService.ListUrls:output_type -> users.ListUrlsResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: users.CreateUserResponse.user:type_name -> users.User
+	0,  // 1: users.GetUserResponse.user:type_name -> users.User
+	0,  // 2: users.GetUserByEmailResponse.user:type_name -> users.User
+	0,  // 3: users.UserSummary.user:type_name -> users.User
+	9,  // 4: users.ListUsersResponse.users:type_name -> users.UserSummary
+	0,  // 5: users.UpdateUserResponse.user:type_name -> users.User
+	0,  // 6: users.RestoreUserResponse.user:type_name -> users.User
+	1,  // 7: users.AddUrlResponse.url:type_name -> users.UserURL
+	1,  // 8: users.ListUrlsResponse.urls:type_name -> users.UserURL
+	1,  // 9: users.UpdateUrlResponse.url:type_name -> users.UserURL
+	1,  // 10: users.PauseUrlResponse.url:type_name -> users.UserURL
+	1,  // 11: users.ResumeUrlResponse.url:type_name -> users.UserURL
+	40, // 12: users.PublishFailure.headers:type_name -> users.PublishFailure.HeadersEntry
+	33, // 13: users.ListPublishFailuresResponse.failures:type_name -> users.PublishFailure
+	2,  // 14: users.UsersService.CreateUser:input_type -> users.CreateUserRequest
+	4,  // 15: users.UsersService.GetUser:input_type -> users.GetUserRequest
+	6,  // 16: users.UsersService.GetUserByEmail:input_type -> users.GetUserByEmailRequest
+	8,  // 17: users.UsersService.ListUsers:input_type -> users.ListUsersRequest
+	11, // 18: users.UsersService.UpdateUser:input_type -> users.UpdateUserRequest
+	13, // 19: users.UsersService.DeleteUser:input_type -> users.DeleteUserRequest
+	15, // 20: users.UsersService.RestoreUser:input_type -> users.RestoreUserRequest
+	17, // 21: users.UsersService.AddUrl:input_type -> users.AddUrlRequest
+	19, // 22: users.UsersService.ListUrls:input_type -> users.ListUrlsRequest
+	21, // 23: users.UsersService.UpdateUrl:input_type -> users.UpdateUrlRequest
+	23, // 24: users.UsersService.PauseUrl:input_type -> users.PauseUrlRequest
+	25, // 25: users.UsersService.ResumeUrl:input_type -> users.ResumeUrlRequest
+	27, // 26: users.UsersService.DeleteUrl:input_type -> users.DeleteUrlRequest
+	29, // 27: users.UsersService.TriggerParse:input_type -> users.TriggerParseRequest
+	31, // 28: users.UsersService.ReportParseResult:input_type -> users.ReportParseResultRequest
+	34, // 29: users.UsersService.ListPublishFailures:input_type -> users.ListPublishFailuresRequest
+	36, // 30: users.UsersService.ReplayPublishFailure:input_type -> users.ReplayPublishFailureRequest
+	38, // 31: users.UsersService.DiscardPublishFailure:input_type -> users.DiscardPublishFailureRequest
+	3,  // 32: users.UsersService.CreateUser:output_type -> users.CreateUserResponse
+	5,  // 33: users.UsersService.GetUser:output_type -> users.GetUserResponse
+	7,  // 34: users.UsersService.GetUserByEmail:output_type -> users.GetUserByEmailResponse
+	10, // 35: users.UsersService.ListUsers:output_type -> users.ListUsersResponse
+	12, // 36: users.UsersService.UpdateUser:output_type -> users.UpdateUserResponse
+	14, // 37: users.UsersService.DeleteUser:output_type -> users.DeleteUserResponse
+	16, // 38: users.UsersService.RestoreUser:output_type -> users.RestoreUserResponse
+	18, // 39: users.UsersService.AddUrl:output_type -> users.AddUrlResponse
+	20, // 40: users.UsersService.ListUrls:output_type -> users.ListUrlsResponse
+	22, // 41: users.UsersService.UpdateUrl:output_type -> users.UpdateUrlResponse
+	24, // 42: users.UsersService.PauseUrl:output_type -> users.PauseUrlResponse
+	26, // 43: users.UsersService.ResumeUrl:output_type -> users.ResumeUrlResponse
+	28, // 44: users.UsersService.DeleteUrl:output_type -> users.DeleteUrlResponse
+	30, // 45: users.UsersService.TriggerParse:output_type -> users.TriggerParseResponse
+	32, // 46: users.UsersService.ReportParseResult:output_type -> users.ReportParseResultResponse
+	35, // 47: users.UsersService.ListPublishFailures:output_type -> users.ListPublishFailuresResponse
+	37, // 48: users.UsersService.ReplayPublishFailure:output_type -> users.ReplayPublishFailureResponse
+	39, // 49: users.UsersService.DiscardPublishFailure:output_type -> users.DiscardPublishFailureResponse
+	32, // [32:50] is the sub-list for method output_type
+	14, // [14:32] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_users_proto_init() }
@@ -653,13 +2597,15 @@ func file_users_proto_init() {
 	if File_users_proto != nil {
 		return
 	}
+	file_users_proto_msgTypes[11].OneofWrappers = []any{}
+	file_users_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_users_proto_rawDesc), len(file_users_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
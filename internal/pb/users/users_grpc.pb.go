@@ -19,10 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UsersService_CreateUser_FullMethodName = "/users.UsersService/CreateUser"
-	UsersService_GetUser_FullMethodName    = "/users.UsersService/GetUser"
-	UsersService_AddUrl_FullMethodName     = "/users.UsersService/AddUrl"
-	UsersService_ListUrls_FullMethodName   = "/users.UsersService/ListUrls"
+	UsersService_CreateUser_FullMethodName            = "/users.UsersService/CreateUser"
+	UsersService_GetUser_FullMethodName               = "/users.UsersService/GetUser"
+	UsersService_GetUserByEmail_FullMethodName        = "/users.UsersService/GetUserByEmail"
+	UsersService_ListUsers_FullMethodName             = "/users.UsersService/ListUsers"
+	UsersService_UpdateUser_FullMethodName            = "/users.UsersService/UpdateUser"
+	UsersService_DeleteUser_FullMethodName            = "/users.UsersService/DeleteUser"
+	UsersService_RestoreUser_FullMethodName           = "/users.UsersService/RestoreUser"
+	UsersService_AddUrl_FullMethodName                = "/users.UsersService/AddUrl"
+	UsersService_ListUrls_FullMethodName              = "/users.UsersService/ListUrls"
+	UsersService_UpdateUrl_FullMethodName             = "/users.UsersService/UpdateUrl"
+	UsersService_PauseUrl_FullMethodName              = "/users.UsersService/PauseUrl"
+	UsersService_ResumeUrl_FullMethodName             = "/users.UsersService/ResumeUrl"
+	UsersService_DeleteUrl_FullMethodName             = "/users.UsersService/DeleteUrl"
+	UsersService_TriggerParse_FullMethodName          = "/users.UsersService/TriggerParse"
+	UsersService_ReportParseResult_FullMethodName     = "/users.UsersService/ReportParseResult"
+	UsersService_ListPublishFailures_FullMethodName   = "/users.UsersService/ListPublishFailures"
+	UsersService_ReplayPublishFailure_FullMethodName  = "/users.UsersService/ReplayPublishFailure"
+	UsersService_DiscardPublishFailure_FullMethodName = "/users.UsersService/DiscardPublishFailure"
 )
 
 // UsersServiceClient is the client API for UsersService service.
@@ -31,8 +45,22 @@ const (
 type UsersServiceClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
+	GetUserByEmail(ctx context.Context, in *GetUserByEmailRequest, opts ...grpc.CallOption) (*GetUserByEmailResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
 	AddUrl(ctx context.Context, in *AddUrlRequest, opts ...grpc.CallOption) (*AddUrlResponse, error)
 	ListUrls(ctx context.Context, in *ListUrlsRequest, opts ...grpc.CallOption) (*ListUrlsResponse, error)
+	UpdateUrl(ctx context.Context, in *UpdateUrlRequest, opts ...grpc.CallOption) (*UpdateUrlResponse, error)
+	PauseUrl(ctx context.Context, in *PauseUrlRequest, opts ...grpc.CallOption) (*PauseUrlResponse, error)
+	ResumeUrl(ctx context.Context, in *ResumeUrlRequest, opts ...grpc.CallOption) (*ResumeUrlResponse, error)
+	DeleteUrl(ctx context.Context, in *DeleteUrlRequest, opts ...grpc.CallOption) (*DeleteUrlResponse, error)
+	TriggerParse(ctx context.Context, in *TriggerParseRequest, opts ...grpc.CallOption) (*TriggerParseResponse, error)
+	ReportParseResult(ctx context.Context, in *ReportParseResultRequest, opts ...grpc.CallOption) (*ReportParseResultResponse, error)
+	ListPublishFailures(ctx context.Context, in *ListPublishFailuresRequest, opts ...grpc.CallOption) (*ListPublishFailuresResponse, error)
+	ReplayPublishFailure(ctx context.Context, in *ReplayPublishFailureRequest, opts ...grpc.CallOption) (*ReplayPublishFailureResponse, error)
+	DiscardPublishFailure(ctx context.Context, in *DiscardPublishFailureRequest, opts ...grpc.CallOption) (*DiscardPublishFailureResponse, error)
 }
 
 type usersServiceClient struct {
@@ -63,6 +91,56 @@ func (c *usersServiceClient) GetUser(ctx context.Context, in *GetUserRequest, op
 	return out, nil
 }
 
+func (c *usersServiceClient) GetUserByEmail(ctx context.Context, in *GetUserByEmailRequest, opts ...grpc.CallOption) (*GetUserByEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserByEmailResponse)
+	err := c.cc.Invoke(ctx, UsersService_GetUserByEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UsersService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateUserResponse)
+	err := c.cc.Invoke(ctx, UsersService_UpdateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserResponse)
+	err := c.cc.Invoke(ctx, UsersService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, UsersService_RestoreUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *usersServiceClient) AddUrl(ctx context.Context, in *AddUrlRequest, opts ...grpc.CallOption) (*AddUrlResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddUrlResponse)
@@ -83,14 +161,118 @@ func (c *usersServiceClient) ListUrls(ctx context.Context, in *ListUrlsRequest,
 	return out, nil
 }
 
+func (c *usersServiceClient) UpdateUrl(ctx context.Context, in *UpdateUrlRequest, opts ...grpc.CallOption) (*UpdateUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateUrlResponse)
+	err := c.cc.Invoke(ctx, UsersService_UpdateUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) PauseUrl(ctx context.Context, in *PauseUrlRequest, opts ...grpc.CallOption) (*PauseUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseUrlResponse)
+	err := c.cc.Invoke(ctx, UsersService_PauseUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ResumeUrl(ctx context.Context, in *ResumeUrlRequest, opts ...grpc.CallOption) (*ResumeUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeUrlResponse)
+	err := c.cc.Invoke(ctx, UsersService_ResumeUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) DeleteUrl(ctx context.Context, in *DeleteUrlRequest, opts ...grpc.CallOption) (*DeleteUrlResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUrlResponse)
+	err := c.cc.Invoke(ctx, UsersService_DeleteUrl_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) TriggerParse(ctx context.Context, in *TriggerParseRequest, opts ...grpc.CallOption) (*TriggerParseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TriggerParseResponse)
+	err := c.cc.Invoke(ctx, UsersService_TriggerParse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ReportParseResult(ctx context.Context, in *ReportParseResultRequest, opts ...grpc.CallOption) (*ReportParseResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportParseResultResponse)
+	err := c.cc.Invoke(ctx, UsersService_ReportParseResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ListPublishFailures(ctx context.Context, in *ListPublishFailuresRequest, opts ...grpc.CallOption) (*ListPublishFailuresResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublishFailuresResponse)
+	err := c.cc.Invoke(ctx, UsersService_ListPublishFailures_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) ReplayPublishFailure(ctx context.Context, in *ReplayPublishFailureRequest, opts ...grpc.CallOption) (*ReplayPublishFailureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayPublishFailureResponse)
+	err := c.cc.Invoke(ctx, UsersService_ReplayPublishFailure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *usersServiceClient) DiscardPublishFailure(ctx context.Context, in *DiscardPublishFailureRequest, opts ...grpc.CallOption) (*DiscardPublishFailureResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscardPublishFailureResponse)
+	err := c.cc.Invoke(ctx, UsersService_DiscardPublishFailure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UsersServiceServer is the server API for UsersService service.
 // All implementations must embed UnimplementedUsersServiceServer
 // for forward compatibility.
 type UsersServiceServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
+	GetUserByEmail(context.Context, *GetUserByEmailRequest) (*GetUserByEmailResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
 	AddUrl(context.Context, *AddUrlRequest) (*AddUrlResponse, error)
 	ListUrls(context.Context, *ListUrlsRequest) (*ListUrlsResponse, error)
+	UpdateUrl(context.Context, *UpdateUrlRequest) (*UpdateUrlResponse, error)
+	PauseUrl(context.Context, *PauseUrlRequest) (*PauseUrlResponse, error)
+	ResumeUrl(context.Context, *ResumeUrlRequest) (*ResumeUrlResponse, error)
+	DeleteUrl(context.Context, *DeleteUrlRequest) (*DeleteUrlResponse, error)
+	TriggerParse(context.Context, *TriggerParseRequest) (*TriggerParseResponse, error)
+	ReportParseResult(context.Context, *ReportParseResultRequest) (*ReportParseResultResponse, error)
+	ListPublishFailures(context.Context, *ListPublishFailuresRequest) (*ListPublishFailuresResponse, error)
+	ReplayPublishFailure(context.Context, *ReplayPublishFailureRequest) (*ReplayPublishFailureResponse, error)
+	DiscardPublishFailure(context.Context, *DiscardPublishFailureRequest) (*DiscardPublishFailureResponse, error)
 	mustEmbedUnimplementedUsersServiceServer()
 }
 
@@ -107,12 +289,54 @@ func (UnimplementedUsersServiceServer) CreateUser(context.Context, *CreateUserRe
 func (UnimplementedUsersServiceServer) GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUser not implemented")
 }
+func (UnimplementedUsersServiceServer) GetUserByEmail(context.Context, *GetUserByEmailRequest) (*GetUserByEmailResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserByEmail not implemented")
+}
+func (UnimplementedUsersServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUsersServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUsersServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUsersServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreUser not implemented")
+}
 func (UnimplementedUsersServiceServer) AddUrl(context.Context, *AddUrlRequest) (*AddUrlResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddUrl not implemented")
 }
 func (UnimplementedUsersServiceServer) ListUrls(context.Context, *ListUrlsRequest) (*ListUrlsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUrls not implemented")
 }
+func (UnimplementedUsersServiceServer) UpdateUrl(context.Context, *UpdateUrlRequest) (*UpdateUrlResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUrl not implemented")
+}
+func (UnimplementedUsersServiceServer) PauseUrl(context.Context, *PauseUrlRequest) (*PauseUrlResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseUrl not implemented")
+}
+func (UnimplementedUsersServiceServer) ResumeUrl(context.Context, *ResumeUrlRequest) (*ResumeUrlResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeUrl not implemented")
+}
+func (UnimplementedUsersServiceServer) DeleteUrl(context.Context, *DeleteUrlRequest) (*DeleteUrlResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteUrl not implemented")
+}
+func (UnimplementedUsersServiceServer) TriggerParse(context.Context, *TriggerParseRequest) (*TriggerParseResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriggerParse not implemented")
+}
+func (UnimplementedUsersServiceServer) ReportParseResult(context.Context, *ReportParseResultRequest) (*ReportParseResultResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReportParseResult not implemented")
+}
+func (UnimplementedUsersServiceServer) ListPublishFailures(context.Context, *ListPublishFailuresRequest) (*ListPublishFailuresResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPublishFailures not implemented")
+}
+func (UnimplementedUsersServiceServer) ReplayPublishFailure(context.Context, *ReplayPublishFailureRequest) (*ReplayPublishFailureResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayPublishFailure not implemented")
+}
+func (UnimplementedUsersServiceServer) DiscardPublishFailure(context.Context, *DiscardPublishFailureRequest) (*DiscardPublishFailureResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscardPublishFailure not implemented")
+}
 func (UnimplementedUsersServiceServer) mustEmbedUnimplementedUsersServiceServer() {}
 func (UnimplementedUsersServiceServer) testEmbeddedByValue()                      {}
 
@@ -170,6 +394,96 @@ func _UsersService_GetUser_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsersService_GetUserByEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).GetUserByEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_GetUserByEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).GetUserByEmail(ctx, req.(*GetUserByEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_RestoreUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UsersService_AddUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddUrlRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +520,168 @@ func _UsersService_ListUrls_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsersService_UpdateUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).UpdateUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_UpdateUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).UpdateUrl(ctx, req.(*UpdateUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_PauseUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).PauseUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_PauseUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).PauseUrl(ctx, req.(*PauseUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ResumeUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ResumeUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_ResumeUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ResumeUrl(ctx, req.(*ResumeUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_DeleteUrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).DeleteUrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_DeleteUrl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).DeleteUrl(ctx, req.(*DeleteUrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_TriggerParse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerParseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).TriggerParse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_TriggerParse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).TriggerParse(ctx, req.(*TriggerParseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ReportParseResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportParseResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ReportParseResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_ReportParseResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ReportParseResult(ctx, req.(*ReportParseResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ListPublishFailures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublishFailuresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ListPublishFailures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_ListPublishFailures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ListPublishFailures(ctx, req.(*ListPublishFailuresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_ReplayPublishFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayPublishFailureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).ReplayPublishFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_ReplayPublishFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).ReplayPublishFailure(ctx, req.(*ReplayPublishFailureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UsersService_DiscardPublishFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardPublishFailureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsersServiceServer).DiscardPublishFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsersService_DiscardPublishFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsersServiceServer).DiscardPublishFailure(ctx, req.(*DiscardPublishFailureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UsersService_ServiceDesc is the grpc.ServiceDesc for UsersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,6 +697,26 @@ var UsersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUser",
 			Handler:    _UsersService_GetUser_Handler,
 		},
+		{
+			MethodName: "GetUserByEmail",
+			Handler:    _UsersService_GetUserByEmail_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UsersService_ListUsers_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UsersService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UsersService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UsersService_RestoreUser_Handler,
+		},
 		{
 			MethodName: "AddUrl",
 			Handler:    _UsersService_AddUrl_Handler,
@@ -229,6 +725,42 @@ var UsersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListUrls",
 			Handler:    _UsersService_ListUrls_Handler,
 		},
+		{
+			MethodName: "UpdateUrl",
+			Handler:    _UsersService_UpdateUrl_Handler,
+		},
+		{
+			MethodName: "PauseUrl",
+			Handler:    _UsersService_PauseUrl_Handler,
+		},
+		{
+			MethodName: "ResumeUrl",
+			Handler:    _UsersService_ResumeUrl_Handler,
+		},
+		{
+			MethodName: "DeleteUrl",
+			Handler:    _UsersService_DeleteUrl_Handler,
+		},
+		{
+			MethodName: "TriggerParse",
+			Handler:    _UsersService_TriggerParse_Handler,
+		},
+		{
+			MethodName: "ReportParseResult",
+			Handler:    _UsersService_ReportParseResult_Handler,
+		},
+		{
+			MethodName: "ListPublishFailures",
+			Handler:    _UsersService_ListPublishFailures_Handler,
+		},
+		{
+			MethodName: "ReplayPublishFailure",
+			Handler:    _UsersService_ReplayPublishFailure_Handler,
+		},
+		{
+			MethodName: "DiscardPublishFailure",
+			Handler:    _UsersService_DiscardPublishFailure_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "users.proto",
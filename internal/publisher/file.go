@@ -0,0 +1,65 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type filePublisher struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+type fileRecord struct {
+	ID      string            `json:"id"`
+	Topic   string            `json:"topic"`
+	Key     string            `json:"key,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Value   json.RawMessage   `json:"value,omitempty"`
+	Raw     []byte            `json:"raw,omitempty"`
+}
+
+// NewStdout writes messages to stdout as JSON lines, for local development.
+func NewStdout() Publisher {
+	return &filePublisher{enc: json.NewEncoder(os.Stdout)}
+}
+
+// NewFile appends messages to path as JSON lines, for local development.
+func NewFile(path string) (Publisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open event file: %w", err)
+	}
+	return &filePublisher{enc: json.NewEncoder(f), closer: f}, nil
+}
+
+func (p *filePublisher) Publish(_ context.Context, msgs ...Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, msg := range msgs {
+		record := fileRecord{ID: msg.ID, Topic: msg.Topic, Key: string(msg.Key), Headers: msg.Headers}
+		// JSON payloads are embedded as is to keep the output readable.
+		if json.Valid(msg.Value) {
+			record.Value = msg.Value
+		} else {
+			record.Raw = msg.Value
+		}
+		if err := p.enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *filePublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+	return p.closer.Close()
+}
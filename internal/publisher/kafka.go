@@ -0,0 +1,45 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/LehaAlexey/Users/internal/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type kafkaPublisher struct {
+	writer kafka.Writer
+}
+
+// NewKafka publishes through a Kafka writer; Message.Topic is the Kafka topic
+// and headers become Kafka record headers.
+func NewKafka(writer kafka.Writer) Publisher {
+	return &kafkaPublisher{writer: writer}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	records := make([]kafkago.Message, 0, len(msgs))
+	for _, msg := range msgs {
+		record := kafkago.Message{
+			Topic: msg.Topic,
+			Key:   msg.Key,
+			Value: msg.Value,
+		}
+		for k, v := range msg.Headers {
+			record.Headers = append(record.Headers, kafkago.Header{Key: k, Value: []byte(v)})
+		}
+		records = append(records, record)
+	}
+
+	err := p.writer.WriteMessages(ctx, records...)
+	var writeErrs kafkago.WriteErrors
+	if errors.As(err, &writeErrs) && len(writeErrs) == len(msgs) {
+		return Errors(writeErrs)
+	}
+	return err
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
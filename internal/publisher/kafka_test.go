@@ -0,0 +1,54 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+type fakeWriter struct {
+	err     error
+	written []kafkago.Message
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafkago.Message) error {
+	w.written = append(w.written, msgs...)
+	return w.err
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+func TestKafkaPublishMapsWriteErrorsPerMessage(t *testing.T) {
+	brokerErr := errors.New("not leader for partition")
+	w := &fakeWriter{err: kafkago.WriteErrors{nil, brokerErr, nil}}
+	p := NewKafka(w)
+
+	err := p.Publish(context.Background(),
+		Message{Topic: "t", Key: []byte("a")},
+		Message{Topic: "t", Key: []byte("b")},
+		Message{Topic: "t", Key: []byte("c")},
+	)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Publish returned %v, want Errors", err)
+	}
+	if len(errs) != 3 || errs[0] != nil || !errors.Is(errs[1], brokerErr) || errs[2] != nil {
+		t.Fatalf("Errors = %v, want only message 1 failed", errs)
+	}
+}
+
+func TestKafkaPublishReturnsBatchErrorAsIs(t *testing.T) {
+	w := &fakeWriter{err: context.DeadlineExceeded}
+	p := NewKafka(w)
+
+	err := p.Publish(context.Background(), Message{Topic: "t"}, Message{Topic: "t"})
+	var errs Errors
+	if errors.As(err, &errs) {
+		t.Fatalf("Publish returned per-message %v for a batch-wide failure", errs)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Publish returned %v, want the writer's error", err)
+	}
+}
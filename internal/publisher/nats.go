@@ -0,0 +1,66 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type natsPublisher struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// NewNATS publishes to NATS JetStream; Message.Topic is the subject. When
+// stream is set it is created or updated to capture the given subjects.
+// The event id is sent as Nats-Msg-Id so the stream drops duplicates.
+func NewNATS(ctx context.Context, url string, stream string, subjects []string) (Publisher, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("nats connect: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("jetstream: %w", err)
+	}
+	if stream != "" {
+		if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{Name: stream, Subjects: subjects}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("jetstream stream %s: %w", stream, err)
+		}
+	}
+	return &natsPublisher{conn: conn, js: js}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	errs := make(Errors, len(msgs))
+	failed := false
+	for i, msg := range msgs {
+		out := nats.NewMsg(msg.Topic)
+		out.Data = msg.Value
+		if msg.ID != "" {
+			out.Header.Set(jetstream.MsgIDHeader, msg.ID)
+		}
+		if len(msg.Key) > 0 {
+			out.Header.Set("Key", string(msg.Key))
+		}
+		for k, v := range msg.Headers {
+			out.Header.Set(k, v)
+		}
+		if _, err := p.js.PublishMsg(ctx, out); err != nil {
+			errs[i] = err
+			failed = true
+		}
+	}
+	if failed {
+		return errs
+	}
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,63 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type postgresPublisher struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgres stores messages in the event_queue table and sends
+// NOTIFY <topic> with the row id, so consumers can LISTEN on the topic and
+// read new rows. Rows are inserted once per event id.
+func NewPostgres(pool *pgxpool.Pool) Publisher {
+	return &postgresPublisher{pool: pool}
+}
+
+func (p *postgresPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	const q = `
+		WITH inserted AS (
+			INSERT INTO event_queue (event_id, topic, message_key, payload, headers)
+			SELECT event_id, topic, message_key, payload, NULLIF(headers, '')::jsonb
+			FROM unnest($1::text[], $2::text[], $3::bytea[], $4::bytea[], $5::text[])
+				AS m(event_id, topic, message_key, payload, headers)
+			ON CONFLICT (event_id) DO NOTHING
+			RETURNING id, topic
+		)
+		SELECT pg_notify(topic, id::text) FROM inserted;
+	`
+	if len(msgs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(msgs))
+	topics := make([]string, len(msgs))
+	keys := make([][]byte, len(msgs))
+	payloads := make([][]byte, len(msgs))
+	headers := make([]string, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.ID
+		topics[i] = msg.Topic
+		keys[i] = msg.Key
+		payloads[i] = msg.Value
+		if len(msg.Headers) > 0 {
+			b, err := json.Marshal(msg.Headers)
+			if err != nil {
+				return fmt.Errorf("marshal headers: %w", err)
+			}
+			headers[i] = string(b)
+		}
+	}
+	if _, err := p.pool.Exec(ctx, q, ids, topics, keys, payloads, headers); err != nil {
+		return fmt.Errorf("event queue insert: %w", err)
+	}
+	return nil
+}
+
+func (p *postgresPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	BackendKafka    = "kafka"
+	BackendNATS     = "nats"
+	BackendPostgres = "postgres"
+	BackendWebhook  = "webhook"
+	BackendStdout   = "stdout"
+	BackendFile     = "file"
+)
+
+// Message is one event to publish. Topic names the destination and each
+// backend maps it onto its own notion of one (Kafka topic, NATS subject,
+// NOTIFY channel, ...). ID is the event id, used for deduplication where the
+// backend supports it.
+type Message struct {
+	ID      string
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Publisher delivers messages to a broker. Publish returns nil only when all
+// messages were accepted; when only some failed it returns Errors.
+type Publisher interface {
+	Publish(ctx context.Context, msgs ...Message) error
+	Close() error
+}
+
+// Errors reports per-message failures of a Publish call: Errors[i] belongs to
+// msgs[i] and is nil for messages that were delivered.
+type Errors []error
+
+func (e Errors) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e {
+		if err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+		}
+		failed++
+	}
+	if first == nil {
+		return "no messages failed"
+	}
+	return fmt.Sprintf("%d of %d messages failed: %v", failed, len(e), first)
+}
@@ -0,0 +1,74 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+type webhookPublisher struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhook POSTs every message to url with the payload as the body. The
+// topic, event id and key are sent as X-Event-Topic, X-Event-Id and
+// X-Event-Key; any non-2xx response counts as a failure.
+func NewWebhook(url string, timeout time.Duration, headers map[string]string) Publisher {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &webhookPublisher{url: url, headers: headers, client: &http.Client{Timeout: timeout}}
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, msgs ...Message) error {
+	errs := make(Errors, len(msgs))
+	failed := false
+	for i, msg := range msgs {
+		if err := p.post(ctx, msg); err != nil {
+			errs[i] = err
+			failed = true
+		}
+	}
+	if failed {
+		return errs
+	}
+	return nil
+}
+
+func (p *webhookPublisher) post(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(msg.Value))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range msg.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("X-Event-Topic", msg.Topic)
+	req.Header.Set("X-Event-Id", msg.ID)
+	req.Header.Set("X-Event-Key", string(msg.Key))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *webhookPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
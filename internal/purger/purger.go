@@ -0,0 +1,61 @@
+package purger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type Storage interface {
+	PurgeDeletedUsers(ctx context.Context, retentionSeconds int, limit int) (int64, error)
+}
+
+type Purger struct {
+	storage      Storage
+	tick         time.Duration
+	retentionSec int
+	maxBatch     int
+}
+
+func New(storage Storage, tick time.Duration, retentionSeconds int, maxBatch int) *Purger {
+	if tick <= 0 {
+		tick = time.Hour
+	}
+	if retentionSeconds <= 0 {
+		retentionSeconds = 30 * 24 * 3600
+	}
+	if maxBatch <= 0 {
+		maxBatch = 100
+	}
+	return &Purger{storage: storage, tick: tick, retentionSec: retentionSeconds, maxBatch: maxBatch}
+}
+
+func (p *Purger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			p.runOnce(ctx)
+		}
+	}
+}
+
+func (p *Purger) runOnce(ctx context.Context) {
+	for {
+		purged, err := p.storage.PurgeDeletedUsers(ctx, p.retentionSec, p.maxBatch)
+		if err != nil {
+			slog.Error("purger: purge deleted users", "error", err.Error())
+			return
+		}
+		if purged > 0 {
+			slog.Info("purger: purged deleted users", "count", purged)
+		}
+		if purged < int64(p.maxBatch) {
+			return
+		}
+	}
+}
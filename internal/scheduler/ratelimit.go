@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"expvar"
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	deferredTotal  = expvar.NewInt("scheduler_deferred_total")
+	deferredByHost = expvar.NewMap("scheduler_deferred_by_host")
+)
+
+// maxHostBuckets caps the number of buckets kept in memory; past it, buckets
+// that have refilled and have nothing queued are dropped.
+const maxHostBuckets = 10000
+
+// otherHosts is the scheduler_deferred_by_host key for hosts without an
+// override, so the metric has one entry per configured domain at most.
+const otherHosts = "other"
+
+// HostLimit is a token bucket: RatePerSecond requests on average with bursts
+// of up to Burst. A zero rate means unlimited.
+type HostLimit struct {
+	RatePerSecond float64
+	Burst         int
+}
+
+// HostLimiter throttles parse requests per host. Overrides are keyed by
+// domain and also apply to its subdomains. Buckets are per process, so with
+// several scheduler instances each one enforces the limit on its own share.
+type HostLimiter struct {
+	defaults  HostLimit
+	overrides map[string]HostLimit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket goes into debt for deferred URLs: a deferral takes its token from
+// the future, so negative tokens are the queue still waiting to be released.
+type bucket struct {
+	limit   HostLimit
+	domain  string
+	tokens  float64
+	updated time.Time
+}
+
+func NewHostLimiter(defaults HostLimit, overrides map[string]HostLimit) *HostLimiter {
+	normalized := make(map[string]HostLimit, len(overrides))
+	for domain, limit := range overrides {
+		normalized[strings.ToLower(strings.TrimPrefix(domain, "."))] = limit
+	}
+	return &HostLimiter{defaults: defaults, overrides: normalized, buckets: make(map[string]*bucket)}
+}
+
+// Reserve takes a token for host. If none is left it still reserves the next
+// free one and returns false with the time it becomes available; the URL must
+// be retried then without calling Reserve again. Deferrals are thereby queued
+// behind each other and released at the bucket rate.
+func (l *HostLimiter) Reserve(host string, now time.Time) (bool, time.Time) {
+	if l == nil {
+		return true, now
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[host]
+	if !ok {
+		if len(l.buckets) >= maxHostBuckets {
+			l.evictIdle(now)
+		}
+		limit, domain := l.limitFor(host)
+		b = &bucket{limit: limit, domain: domain, tokens: float64(max(limit.Burst, 1)), updated: now}
+		l.buckets[host] = b
+	}
+	if b.limit.RatePerSecond <= 0 {
+		return true, now
+	}
+
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(max(b.limit.Burst, 1)), b.tokens+elapsed*b.limit.RatePerSecond)
+		b.updated = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return true, now
+	}
+
+	wait := -b.tokens / b.limit.RatePerSecond
+	deferredTotal.Add(1)
+	deferredByHost.Add(b.domain, 1)
+	return false, now.Add(time.Duration(wait * float64(time.Second)))
+}
+
+// evictIdle drops buckets that have refilled completely; they behave the same
+// as a fresh bucket.
+func (l *HostLimiter) evictIdle(now time.Time) {
+	for host, b := range l.buckets {
+		if b.limit.RatePerSecond <= 0 || b.tokens+now.Sub(b.updated).Seconds()*b.limit.RatePerSecond >= float64(max(b.limit.Burst, 1)) {
+			delete(l.buckets, host)
+		}
+	}
+}
+
+// limitFor returns the limit for host and the override domain it came from,
+// or otherHosts for the defaults.
+func (l *HostLimiter) limitFor(host string) (HostLimit, string) {
+	for domain := host; domain != ""; {
+		if limit, ok := l.overrides[domain]; ok {
+			return limit, domain
+		}
+		i := strings.IndexByte(domain, '.')
+		if i < 0 {
+			break
+		}
+		domain = domain[i+1:]
+	}
+	return l.defaults, otherHosts
+}
+
+func hostOf(normalizedURL string) string {
+	parsed, err := url.Parse(normalizedURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
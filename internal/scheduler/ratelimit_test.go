@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestHostLimiterQueuesDeferrals(t *testing.T) {
+	limiter := NewHostLimiter(HostLimit{RatePerSecond: 10, Burst: 1}, nil)
+	start := time.Now()
+
+	if ok, _ := limiter.Reserve("shop.example", start); !ok {
+		t.Fatal("first reservation deferred, want allowed")
+	}
+	var last time.Time
+	for i := 1; i <= 5; i++ {
+		now := start.Add(time.Duration(i) * time.Millisecond)
+		ok, until := limiter.Reserve("shop.example", now)
+		if ok {
+			t.Fatalf("reservation %d allowed, want deferred", i)
+		}
+		want := start.Add(time.Duration(i) * 100 * time.Millisecond)
+		if d := until.Sub(want); d < -time.Millisecond || d > time.Millisecond {
+			t.Fatalf("reservation %d deferred until %v, want about %v", i, until.Sub(start), want.Sub(start))
+		}
+		if !until.After(last) {
+			t.Fatalf("reservation %d not queued behind the previous one", i)
+		}
+		last = until
+	}
+}
+
+func TestHostLimiterBoundsMetricKeys(t *testing.T) {
+	limiter := NewHostLimiter(HostLimit{RatePerSecond: 1, Burst: 1}, map[string]HostLimit{
+		"example.com": {RatePerSecond: 1, Burst: 1},
+	})
+	if _, domain := limiter.limitFor("a.b.example.com"); domain != "example.com" {
+		t.Fatalf("override domain = %q, want example.com", domain)
+	}
+	if _, domain := limiter.limitFor("unknown.org"); domain != otherHosts {
+		t.Fatalf("default domain = %q, want %q", domain, otherHosts)
+	}
+}
+
+func TestHostLimiterEvictsIdleBuckets(t *testing.T) {
+	limiter := NewHostLimiter(HostLimit{RatePerSecond: 1, Burst: 1}, nil)
+	now := time.Now()
+	for i := 0; i < maxHostBuckets; i++ {
+		limiter.Reserve(fmt.Sprintf("host-%d.example", i), now)
+	}
+	limiter.Reserve("late.example", now.Add(time.Minute))
+	if n := len(limiter.buckets); n != 1 {
+		t.Fatalf("%d buckets after eviction, want 1", n)
+	}
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+)
+
+// Lanes routes parse requests by priority: requests at or above Threshold go
+// to High so parsers can serve them first. An empty High or a zero Threshold
+// keeps every request on Regular; the priority is still carried in the event.
+type Lanes struct {
+	Regular   string
+	High      string
+	Threshold int
+}
+
+func (l Lanes) TopicFor(priority int) string {
+	if l.High != "" && l.Threshold > 0 && priority >= l.Threshold {
+		return l.High
+	}
+	return l.Regular
+}
+
+// ParseRequest builds the outbox message asking the parser to fetch item,
+// encoded with codec. scheduledAt is the slot being served; together with the
+// URL id it forms the idempotency key.
+func ParseRequest(item models.UserURL, correlationID string, scheduledAt time.Time, topic string, codec events.Codec) (models.OutboxMessage, error) {
+	msg := events.ParseRequested{
+		EventID:        events.NewEventID(),
+		OccurredAt:     time.Now().UTC(),
+		CorrelationID:  correlationID,
+		IdempotencyKey: events.IdempotencyKey(item.ID, scheduledAt),
+		ProductID:      item.ID,
+		URL:            item.URL,
+		ScheduledAt:    scheduledAt.UTC(),
+		Priority:       item.Priority,
+	}
+	payload, headers, err := codec.ParseRequested(msg)
+	if err != nil {
+		return models.OutboxMessage{}, err
+	}
+	return models.OutboxMessage{
+		EventID: msg.EventID,
+		URLID:   item.ID,
+		Topic:   topic,
+		Key:     []byte(item.ID),
+		Payload: payload,
+		Headers: headers,
+	}, nil
+}
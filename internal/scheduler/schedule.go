@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule yields the run times of a URL.
+type Schedule interface {
+	// Next returns the first run time strictly after t.
+	Next(t time.Time) time.Time
+}
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ParseSchedule builds the schedule of a URL: a standard five-field cron
+// expression evaluated in timezone (UTC if empty), or a fixed interval when
+// cronExpr is empty. Time windows are expressed in the cron hour/weekday
+// fields, e.g. "*/15 8-19 * * *" or "0 9 * * 1-5".
+func ParseSchedule(cronExpr string, timezone string, intervalSeconds int) (Schedule, error) {
+	cronExpr = strings.TrimSpace(cronExpr)
+	if cronExpr == "" {
+		if intervalSeconds <= 0 {
+			return nil, fmt.Errorf("interval must be positive")
+		}
+		return intervalSchedule(time.Duration(intervalSeconds) * time.Second), nil
+	}
+
+	loc := time.UTC
+	if tz := strings.TrimSpace(timezone); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", tz)
+		}
+		loc = l
+	}
+
+	spec, err := cronParser.Parse(cronExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	if spec.Next(time.Now().In(loc)).IsZero() {
+		return nil, fmt.Errorf("cron expression never fires")
+	}
+	return cronSchedule{spec: spec, loc: loc}, nil
+}
+
+// intervalSchedule fires on a fixed grid of multiples of the interval since
+// the Unix epoch, so run times never drift with processing delays.
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	d := int64(s)
+	ns := t.UnixNano()
+	slot := ns - ns%d
+	if ns%d < 0 {
+		slot -= d
+	}
+	return time.Unix(0, slot+d).UTC()
+}
+
+type cronSchedule struct {
+	spec cron.Schedule
+	loc  *time.Location
+}
+
+func (s cronSchedule) Next(t time.Time) time.Time {
+	return s.spec.Next(t.In(s.loc)).UTC()
+}
+
+// Backoff stretches the gap between runs of failing URLs: the regular gap
+// doubles with each consecutive failure up to MaxSeconds, plus up to Jitter
+// (a fraction of the delay) of random spread.
+type Backoff struct {
+	MaxSeconds int
+	Jitter     float64
+}
+
+func (b Backoff) delay(gap time.Duration, failures int) time.Duration {
+	maxDelay := time.Duration(b.MaxSeconds) * time.Second
+	if maxDelay < gap {
+		maxDelay = gap
+	}
+
+	d := float64(gap) * math.Pow(2, float64(min(failures, 30)))
+	d = math.Min(d, float64(maxDelay))
+	if b.Jitter > 0 {
+		d += d * b.Jitter * rand.Float64()
+	}
+	return time.Duration(d)
+}
+
+// nextRun returns the slot following the run planned for prev. Failing URLs
+// are pushed back by the backoff and re-aligned to the schedule.
+func nextRun(schedule Schedule, backoff Backoff, catchUp string, failures int, prev time.Time, now time.Time) time.Time {
+	next := schedule.Next(now)
+	if failures > 0 {
+		gap := schedule.Next(next).Sub(next)
+		return schedule.Next(now.Add(backoff.delay(gap, failures) - gap))
+	}
+	if catchUp == CatchUpAll && prev.Before(now) {
+		return schedule.Next(prev)
+	}
+	return next
+}
+
+const (
+	CatchUpSkip = "skip"
+	CatchUpAll  = "all"
+)
+
+// SlotPolicy spreads URLs over their schedule. Each URL gets a deterministic
+// phase derived from its id: interval URLs are offset by up to JitterFraction
+// of the interval (the whole interval when nil, none when 0), cron URLs by up
+// to CronJitter. CatchUp decides what happens
+// to slots missed while the scheduler was behind: CatchUpSkip drops them,
+// CatchUpAll runs each of them in turn.
+type SlotPolicy struct {
+	JitterFraction *float64
+	CronJitter     time.Duration
+	InitialSpread  time.Duration
+	CatchUp        string
+}
+
+// defaultJitterFraction spreads interval URLs over their whole interval, so
+// URLs sharing an interval never fire on the same grid point.
+const defaultJitterFraction = 1.0
+
+// phased shifts every run time of a schedule by a constant offset.
+type phased struct {
+	Schedule
+	phase time.Duration
+}
+
+func (s phased) Next(t time.Time) time.Time {
+	return s.Schedule.Next(t.Add(-s.phase)).Add(s.phase)
+}
+
+func (p SlotPolicy) apply(schedule Schedule, urlID string) Schedule {
+	var span time.Duration
+	switch sch := schedule.(type) {
+	case intervalSchedule:
+		fraction := defaultJitterFraction
+		if p.JitterFraction != nil {
+			fraction = *p.JitterFraction
+		}
+		span = time.Duration(float64(sch) * math.Min(fraction, 1))
+	case cronSchedule:
+		span = p.CronJitter
+	}
+	if span <= 0 {
+		return schedule
+	}
+	return phased{Schedule: schedule, phase: offset(urlID, span)}
+}
+
+// FirstRun is when a newly added URL runs for the first time: interval URLs
+// run right away, spread over InitialSpread so a burst of additions does not
+// fire at once; cron URLs wait for their first slot, phased as the scheduler
+// phases every later one.
+func (p SlotPolicy) FirstRun(schedule Schedule, urlID string, now time.Time) time.Time {
+	if _, ok := schedule.(intervalSchedule); ok {
+		if p.InitialSpread <= 0 {
+			return now
+		}
+		return now.Add(offset(urlID, p.InitialSpread))
+	}
+	return p.apply(schedule, urlID).Next(now)
+}
+
+func offset(key string, span time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	if span < time.Second {
+		return time.Duration(h.Sum64() % uint64(span))
+	}
+	return time.Duration(h.Sum64()%uint64(span/time.Second)) * time.Second
+}
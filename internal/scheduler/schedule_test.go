@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntervalScheduleUsesUnixEpochGrid(t *testing.T) {
+	// 7 minutes does not divide an hour, so a grid anchored anywhere but the
+	// Unix epoch would give different slots.
+	s := intervalSchedule(7 * time.Minute)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	next := s.Next(at)
+	if next.Unix()%(7*60) != 0 {
+		t.Fatalf("Next(%v) = %v, not a multiple of the interval since the epoch", at, next)
+	}
+	if !next.After(at) || next.Sub(at) > 7*time.Minute {
+		t.Fatalf("Next(%v) = %v, want within one interval after it", at, next)
+	}
+	if again := s.Next(next); again.Sub(next) != 7*time.Minute {
+		t.Fatalf("Next(%v) = %v, want one interval later", next, again)
+	}
+}
+
+func TestSlotPolicySpreadsIntervalURLsByDefault(t *testing.T) {
+	var p SlotPolicy
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	seen := make(map[time.Time]bool)
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seen[p.apply(intervalSchedule(time.Hour), id).Next(at)] = true
+	}
+	if len(seen) < 2 {
+		t.Fatal("URLs with the same interval share one slot with an unset jitter_fraction")
+	}
+}
+
+func TestSlotPolicyZeroJitterFractionDisablesPhase(t *testing.T) {
+	zero := 0.0
+	p := SlotPolicy{JitterFraction: &zero}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, id := range []string{"a", "b", "c"} {
+		if next := p.apply(intervalSchedule(time.Hour), id).Next(at); !next.Equal(at.Add(time.Hour)) {
+			t.Fatalf("url %s: Next = %v, want the unphased slot %v", id, next, at.Add(time.Hour))
+		}
+	}
+}
+
+func TestSlotPolicyFirstRunPhasesCronURLs(t *testing.T) {
+	p := SlotPolicy{CronJitter: time.Hour}
+	schedule, err := ParseSchedule("0 9 * * *", "UTC", 0)
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	now := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+
+	seen := make(map[time.Time]bool)
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		first := p.FirstRun(schedule, id, now)
+		if want := p.apply(schedule, id).Next(now); !first.Equal(want) {
+			t.Fatalf("url %s: FirstRun = %v, want the scheduler's phased slot %v", id, first, want)
+		}
+		seen[first] = true
+	}
+	if len(seen) < 2 {
+		t.Fatal("cron URLs on the same expression share one first slot")
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  Backoff
+		gap      time.Duration
+		failures int
+		want     time.Duration
+	}{
+		{"no failures", Backoff{MaxSeconds: 3600}, time.Minute, 0, time.Minute},
+		{"one failure doubles", Backoff{MaxSeconds: 3600}, time.Minute, 1, 2 * time.Minute},
+		{"n failures", Backoff{MaxSeconds: 3600}, time.Minute, 3, 8 * time.Minute},
+		{"capped at max", Backoff{MaxSeconds: 3600}, time.Minute, 10, time.Hour},
+		{"huge failure count stays capped", Backoff{MaxSeconds: 3600}, time.Minute, 1000, time.Hour},
+		{"max below the gap keeps the gap", Backoff{MaxSeconds: 10}, time.Minute, 5, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.delay(tt.gap, tt.failures); got != tt.want {
+				t.Fatalf("delay(%v, %d) = %v, want %v", tt.gap, tt.failures, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelayJitterStaysWithinFraction(t *testing.T) {
+	b := Backoff{MaxSeconds: 3600, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		got := b.delay(time.Minute, 1)
+		if got < 2*time.Minute || got > 2*time.Minute+24*time.Second {
+			t.Fatalf("delay = %v, want within [2m, 2m24s]", got)
+		}
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 1, h, m, 0, 0, time.UTC) }
+	hourly := intervalSchedule(time.Hour)
+
+	tests := []struct {
+		name     string
+		backoff  Backoff
+		catchUp  string
+		failures int
+		prev     time.Time
+		want     time.Time
+	}{
+		{"skip drops missed slots", Backoff{}, CatchUpSkip, 0, at(10, 0), at(13, 0)},
+		{"all runs the next missed slot", Backoff{}, CatchUpAll, 0, at(10, 0), at(11, 0)},
+		{"all with nothing missed", Backoff{}, CatchUpAll, 0, at(13, 0), at(13, 0)},
+		{"one failure skips a slot", Backoff{MaxSeconds: 86400}, CatchUpSkip, 1, at(12, 0), at(14, 0)},
+		{"n failures", Backoff{MaxSeconds: 86400}, CatchUpSkip, 3, at(12, 0), at(20, 0)},
+		{"failures capped at max", Backoff{MaxSeconds: 4 * 3600}, CatchUpSkip, 5, at(12, 0), at(16, 0)},
+		{"failures override catch-up", Backoff{MaxSeconds: 86400}, CatchUpAll, 1, at(10, 0), at(14, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextRun(hourly, tt.backoff, tt.catchUp, tt.failures, tt.prev, now)
+			if !got.Equal(tt.want) {
+				t.Fatalf("nextRun = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,31 +2,35 @@ package scheduler
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log/slog"
 	"time"
 
-	"github.com/LehaAlexey/Users/internal/kafka"
+	"github.com/LehaAlexey/Users/internal/correlation"
 	"github.com/LehaAlexey/Users/internal/models"
 	"github.com/LehaAlexey/Users/internal/models/events"
-	kafkago "github.com/segmentio/kafka-go"
 )
 
 type Storage interface {
-	GetDueURLs(ctx context.Context, limit int) ([]models.UserURL, error)
-	MarkScheduled(ctx context.Context, urlID string, intervalSeconds int) error
+	ClaimDueURLs(ctx context.Context, limit int, lease time.Duration, fairness models.FairnessPolicy) ([]models.UserURL, error)
+	MarkScheduled(ctx context.Context, runs []models.ScheduledRun) (int, error)
+	DeferURLs(ctx context.Context, runs []models.DeferredRun) error
 }
 
 type Scheduler struct {
 	storage     Storage
-	writer      kafka.Writer
+	lanes       Lanes
+	codec       events.Codec
 	tick        time.Duration
 	intervalSec int
 	maxBatch    int
+	claimLease  time.Duration
+	backoff     Backoff
+	slots       SlotPolicy
+	limiter     *HostLimiter
+	fairness    models.FairnessPolicy
 }
 
-func New(storage Storage, writer kafka.Writer, tick time.Duration, intervalSeconds int, maxBatch int) *Scheduler {
+func New(storage Storage, lanes Lanes, codec events.Codec, tick time.Duration, intervalSeconds int, maxBatch int, claimLease time.Duration, backoff Backoff, slots SlotPolicy, limiter *HostLimiter, fairness models.FairnessPolicy) *Scheduler {
 	if tick <= 0 {
 		tick = 5 * time.Second
 	}
@@ -36,7 +40,36 @@ func New(storage Storage, writer kafka.Writer, tick time.Duration, intervalSecon
 	if maxBatch <= 0 {
 		maxBatch = 100
 	}
-	return &Scheduler{storage: storage, writer: writer, tick: tick, intervalSec: intervalSeconds, maxBatch: maxBatch}
+	if claimLease <= 0 {
+		claimLease = 30 * time.Second
+	}
+	if backoff.MaxSeconds <= 0 {
+		backoff.MaxSeconds = 24 * 3600
+	}
+	if backoff.Jitter < 0 {
+		backoff.Jitter = 0
+	}
+	if slots.CatchUp == "" {
+		slots.CatchUp = CatchUpSkip
+	}
+	return &Scheduler{
+		storage:     storage,
+		lanes:       lanes,
+		codec:       codec,
+		tick:        tick,
+		intervalSec: intervalSeconds,
+		maxBatch:    maxBatch,
+		claimLease:  claimLease,
+		backoff:     backoff,
+		slots:       slots,
+		limiter:     limiter,
+		fairness:    fairness,
+	}
+}
+
+// Status reports claim mode: every instance schedules, none is a leader.
+func (s *Scheduler) Status() models.SchedulerStatus {
+	return models.SchedulerStatus{Mode: models.SchedulerModeClaim, Active: true}
 }
 
 func (s *Scheduler) Run(ctx context.Context) error {
@@ -53,38 +86,45 @@ func (s *Scheduler) Run(ctx context.Context) error {
 	}
 }
 
+// runOnce drains due URLs batch by batch. Each batch costs one claim, one
+// update for the scheduled runs and, if the host limiter kicked in, one for
+// the deferred ones.
 func (s *Scheduler) runOnce(ctx context.Context) {
-	urls, err := s.storage.GetDueURLs(ctx, s.maxBatch)
-	if err != nil {
-		slog.Error("scheduler: get due urls", "error", err.Error())
-		return
-	}
-	if len(urls) == 0 {
-		return
+	for ctx.Err() == nil {
+		urls, err := s.storage.ClaimDueURLs(ctx, s.maxBatch, s.claimLease, s.fairness)
+		if err != nil {
+			slog.Error("scheduler: claim due urls", "error", err.Error())
+			return
+		}
+		if len(urls) == 0 {
+			return
+		}
+
+		// A short batch does not mean the backlog is drained: per-user quotas
+		// cap a batch below maxBatch when few users have due URLs.
+		s.schedule(ctx, urls)
 	}
+}
 
+func (s *Scheduler) schedule(ctx context.Context, urls []models.UserURL) {
+	runs := make([]models.ScheduledRun, 0, len(urls))
+	var deferred []models.DeferredRun
 	for _, item := range urls {
-		msg := events.ParseRequested{
-			EventID:       newEventID(),
-			OccurredAt:    time.Now().UTC(),
-			CorrelationID: newEventID(),
-			ProductID:     item.ID,
-			URL:           item.URL,
-			ScheduledAt:   time.Now().UTC(),
-			Priority:      0,
+		// A URL back from a deferral already holds the token it waited for.
+		if item.DeferredUntil == nil {
+			if ok, until := s.limiter.Reserve(hostOf(item.NormalizedURL), time.Now()); !ok {
+				deferred = append(deferred, models.DeferredRun{URLID: item.ID, ClaimID: item.ClaimID, Until: until})
+				continue
+			}
 		}
 
-		payload, err := json.Marshal(&msg)
-		if err != nil {
-			slog.Error("scheduler: marshal", "error", err.Error())
-			continue
+		correlationID := item.CorrelationID
+		if correlationID == "" {
+			correlationID = correlation.NewID()
 		}
-
-		if err := s.writer.WriteMessages(ctx, kafkago.Message{
-			Key:   []byte(item.ID),
-			Value: payload,
-		}); err != nil {
-			slog.Error("scheduler: kafka write", "error", err.Error())
+		event, err := ParseRequest(item, correlationID, item.NextRunAt, s.lanes.TopicFor(item.Priority), s.codec)
+		if err != nil {
+			slog.Error("scheduler: marshal", "url_id", item.ID, "error", err.Error())
 			continue
 		}
 
@@ -92,12 +132,29 @@ func (s *Scheduler) runOnce(ctx context.Context) {
 		if interval <= 0 {
 			interval = s.intervalSec
 		}
-		if err := s.storage.MarkScheduled(ctx, item.ID, interval); err != nil {
-			slog.Error("scheduler: mark scheduled", "error", err.Error())
+		schedule, err := ParseSchedule(item.CronExpr, item.Timezone, interval)
+		if err != nil {
+			slog.Error("scheduler: parse schedule", "url_id", item.ID, "error", err.Error())
+			schedule = intervalSchedule(time.Duration(interval) * time.Second)
 		}
+		schedule = s.slots.apply(schedule, item.ID)
+		runs = append(runs, models.ScheduledRun{
+			URLID:     item.ID,
+			ClaimID:   item.ClaimID,
+			NextRunAt: nextRun(schedule, s.backoff, s.slots.CatchUp, item.ConsecutiveFailures, item.NextRunAt, time.Now().UTC()),
+			Event:     event,
+		})
 	}
-}
 
-func newEventID() string {
-	return fmt.Sprintf("%d", time.Now().UnixNano())
+	if err := s.storage.DeferURLs(ctx, deferred); err != nil {
+		slog.Error("scheduler: defer urls", "count", len(deferred), "error", err.Error())
+	}
+	applied, err := s.storage.MarkScheduled(ctx, runs)
+	if err != nil {
+		slog.Error("scheduler: mark scheduled", "count", len(runs), "error", err.Error())
+		return
+	}
+	if lost := len(runs) - applied; lost > 0 {
+		slog.Warn("scheduler: claims lost before scheduling", "count", lost)
+	}
 }
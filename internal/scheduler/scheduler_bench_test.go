@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+)
+
+// fakeStorage hands out a fixed backlog of due URLs batch by batch.
+type fakeStorage struct {
+	due       []models.UserURL
+	next      int
+	scheduled int
+}
+
+func (f *fakeStorage) ClaimDueURLs(_ context.Context, limit int, _ time.Duration, _ models.FairnessPolicy) ([]models.UserURL, error) {
+	end := min(f.next+limit, len(f.due))
+	batch := f.due[f.next:end]
+	f.next = end
+	return batch, nil
+}
+
+func (f *fakeStorage) MarkScheduled(_ context.Context, runs []models.ScheduledRun) (int, error) {
+	f.scheduled += len(runs)
+	return len(runs), nil
+}
+
+func (f *fakeStorage) DeferURLs(context.Context, []models.DeferredRun) error {
+	return nil
+}
+
+// BenchmarkRunOnce100k measures scheduling a backlog of 100k due URLs, one
+// claim and one MarkScheduled per batch, without a database.
+func BenchmarkRunOnce100k(b *testing.B) {
+	const backlog = 100000
+	due := make([]models.UserURL, backlog)
+	now := time.Now().Add(-time.Minute)
+	for i := range due {
+		id := fmt.Sprintf("00000000-0000-0000-0000-%012d", i)
+		due[i] = models.UserURL{
+			ID:                     id,
+			URL:                    fmt.Sprintf("https://shop%d.example/item/%d", i%1000, i),
+			NormalizedURL:          fmt.Sprintf("https://shop%d.example/item/%d", i%1000, i),
+			PollingIntervalSeconds: 3600,
+			NextRunAt:              now,
+			ClaimID:                id,
+		}
+	}
+	storage := &fakeStorage{due: due}
+	jitter := 0.1
+	s := New(storage, Lanes{Regular: "parse_requested"}, events.Codec{}, time.Second, 3600, 500, time.Minute, Backoff{}, SlotPolicy{JitterFraction: &jitter}, nil, models.FairnessPolicy{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		storage.next, storage.scheduled = 0, 0
+		s.runOnce(context.Background())
+		if storage.scheduled != backlog {
+			b.Fatalf("scheduled %d URLs, want %d", storage.scheduled, backlog)
+		}
+	}
+	b.ReportMetric(float64(backlog*b.N)/b.Elapsed().Seconds(), "urls/s")
+}
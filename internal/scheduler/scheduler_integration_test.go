@@ -0,0 +1,85 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+	"github.com/LehaAlexey/Users/internal/scheduler"
+	"github.com/LehaAlexey/Users/internal/storage/pgstorage"
+	"github.com/LehaAlexey/Users/internal/testdb"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TestConcurrentSchedulersEmitEachURLOnce runs several schedulers against one
+// database and checks that every due URL gets exactly one ParseRequested per
+// interval.
+func TestConcurrentSchedulersEmitEachURLOnce(t *testing.T) {
+	const (
+		instances = 4
+		urlCount  = 500
+	)
+	pool := testdb.New(t)
+	storage := pgstorage.New(pool)
+
+	for u := 0; u < 10; u++ {
+		user := testdb.CreateUser(t, pool, fmt.Sprintf("user%d@example.com", u))
+		for i := 0; i < urlCount/10; i++ {
+			testdb.AddURL(t, pool, user, fmt.Sprintf("https://shop%d.example/%d", u, i), time.Now().Add(-time.Minute))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < instances; i++ {
+		s := scheduler.New(storage, scheduler.Lanes{Regular: "parse_requested"}, events.Codec{}, 10*time.Millisecond, 3600, 25, time.Minute,
+			scheduler.Backoff{}, scheduler.SlotPolicy{}, nil, models.FairnessPolicy{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = s.Run(ctx)
+		}()
+	}
+
+	deadline := time.Now().Add(20 * time.Second)
+	for countOutbox(t, pool) < urlCount && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	// Let the instances keep ticking for a while: nothing else is due, so any
+	// further event would be a duplicate.
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	var emitted, urls, pending int
+	err := pool.QueryRow(context.Background(), `
+		SELECT count(*), count(DISTINCT url_id),
+			(SELECT count(*) FROM user_urls WHERE next_run_at <= now())
+		FROM outbox
+	`).Scan(&emitted, &urls, &pending)
+	if err != nil {
+		t.Fatalf("count outbox: %v", err)
+	}
+	if urls != urlCount {
+		t.Fatalf("%d URLs emitted, want %d", urls, urlCount)
+	}
+	if emitted != urlCount {
+		t.Fatalf("%d events for %d URLs, want one each", emitted, urls)
+	}
+	if pending != 0 {
+		t.Fatalf("%d URLs still due after scheduling", pending)
+	}
+}
+
+func countOutbox(t *testing.T, pool *pgxpool.Pool) int {
+	t.Helper()
+	var n int
+	if err := pool.QueryRow(context.Background(), `SELECT count(*) FROM outbox`).Scan(&n); err != nil {
+		t.Fatalf("count outbox: %v", err)
+	}
+	return n
+}
@@ -0,0 +1,52 @@
+package userservice
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/LehaAlexey/Users/internal/models"
+)
+
+// ListPublishFailures returns messages the outbox relay gave up on, oldest
+// first. afterID is the last id of the previous page; empty starts from the
+// beginning.
+func (s *Service) ListPublishFailures(ctx context.Context, afterID string, limit int) ([]models.PublishFailure, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	var after int64
+	if afterID = strings.TrimSpace(afterID); afterID != "" {
+		v, err := strconv.ParseInt(afterID, 10, 64)
+		if err != nil || v < 0 {
+			return nil, InvalidArgument("after_id", "must be a non-negative integer")
+		}
+		after = v
+	}
+	return s.storage.ListPublishFailures(ctx, after, limit)
+}
+
+// ReplayPublishFailure queues the message for publishing again.
+func (s *Service) ReplayPublishFailure(ctx context.Context, id string) error {
+	failureID, err := publishFailureID(id)
+	if err != nil {
+		return err
+	}
+	return s.storage.ReplayPublishFailure(ctx, failureID)
+}
+
+func (s *Service) DiscardPublishFailure(ctx context.Context, id string) error {
+	failureID, err := publishFailureID(id)
+	if err != nil {
+		return err
+	}
+	return s.storage.DiscardPublishFailure(ctx, failureID)
+}
+
+func publishFailureID(raw string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, InvalidArgument("id", "must be a positive integer")
+	}
+	return id, nil
+}
@@ -0,0 +1,74 @@
+package userservice
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/google/uuid"
+)
+
+// pageCursor is the decoded form of an opaque page token. It records the sort
+// and filters it was issued for, so a token cannot be replayed against a
+// different listing.
+type pageCursor struct {
+	Sort    string `json:"s"`
+	Filters string `json:"f,omitempty"`
+	Value   string `json:"v"`
+	ID      string `json:"id"`
+}
+
+func encodeCursor(c pageCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(raw string) (pageCursor, error) {
+	var c pageCursor
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, InvalidArgument("cursor", "malformed cursor")
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, InvalidArgument("cursor", "malformed cursor")
+	}
+	if _, err := uuid.Parse(c.ID); err != nil {
+		return c, InvalidArgument("cursor", "malformed cursor")
+	}
+	// The value goes to storage as the sort column's type; check it here so a
+	// tampered token is a validation error, not a failed query.
+	switch strings.TrimPrefix(c.Sort, "-") {
+	case models.URLSortURL:
+		if !utf8.ValidString(c.Value) || strings.ContainsRune(c.Value, 0) {
+			return c, InvalidArgument("cursor", "malformed cursor")
+		}
+	default:
+		if _, err := time.Parse(time.RFC3339Nano, c.Value); err != nil {
+			return c, InvalidArgument("cursor", "malformed cursor")
+		}
+	}
+	return c, nil
+}
+
+// urlSortValue returns the value of the sort column for u as the storage
+// layer expects it in a keyset position.
+func urlSortValue(u models.UserURL, sort string) string {
+	switch sort {
+	case models.URLSortNextRunAt:
+		return u.NextRunAt.UTC().Format(time.RFC3339Nano)
+	case models.URLSortURL:
+		return u.URL
+	default:
+		return u.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func formatTimeFilter(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
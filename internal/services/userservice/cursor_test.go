@@ -0,0 +1,38 @@
+package userservice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+)
+
+func TestDecodeCursorRoundTrip(t *testing.T) {
+	u := models.UserURL{ID: "5b0a9f8e-6d1c-4c3e-9a57-0f2c7d1b9e11", URL: "https://shop.example/a", CreatedAt: time.Now()}
+	for _, sort := range []string{"-created_at", "next_run_at", "url"} {
+		want := pageCursor{Sort: sort, Filters: "||", Value: urlSortValue(u, strings.TrimPrefix(sort, "-")), ID: u.ID}
+		got, err := decodeCursor(encodeCursor(want))
+		if err != nil {
+			t.Fatalf("sort %s: decode: %v", sort, err)
+		}
+		if got != want {
+			t.Fatalf("sort %s: decoded %+v, want %+v", sort, got, want)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsTamperedValue(t *testing.T) {
+	id := "5b0a9f8e-6d1c-4c3e-9a57-0f2c7d1b9e11"
+	for _, c := range []pageCursor{
+		{Sort: "-created_at", Value: "yesterday", ID: id},
+		{Sort: "next_run_at", Value: "", ID: id},
+		{Sort: "url", Value: "a\x00b", ID: id},
+		{Sort: "url", Value: "https://shop.example/", ID: "not-a-uuid"},
+	} {
+		if _, err := decodeCursor(encodeCursor(c)); !errors.Is(err, ErrInvalidArgument) {
+			t.Fatalf("cursor %+v: got %v, want an invalid argument error", c, err)
+		}
+	}
+}
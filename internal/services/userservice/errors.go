@@ -0,0 +1,76 @@
+package userservice
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrConflict        = errors.New("conflict")
+	ErrInternal        = errors.New("internal error")
+)
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Error is a domain error returned by the service. Kind is one of the Err*
+// sentinels, so callers can match it with errors.Is; Message is safe to show
+// to API clients, while Err keeps the underlying cause for logging.
+type Error struct {
+	Kind       error
+	Message    string
+	Violations []FieldViolation
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil && e.Kind == ErrInternal {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(msg string, cause error) error {
+	return &Error{Kind: ErrNotFound, Message: msg, Err: cause}
+}
+
+func AlreadyExists(msg string, cause error) error {
+	return &Error{Kind: ErrAlreadyExists, Message: msg, Err: cause}
+}
+
+func InvalidArgument(field string, msg string) error {
+	e := &Error{Kind: ErrInvalidArgument, Message: msg}
+	if field != "" {
+		e.Violations = []FieldViolation{{Field: field, Description: msg}}
+	}
+	return e
+}
+
+// Conflict reports a request that the current state of the resource does not
+// allow, such as triggering a paused URL.
+func Conflict(msg string) error {
+	return &Error{Kind: ErrConflict, Message: msg}
+}
+
+func RateLimited(msg string, retryAfter time.Duration) error {
+	return &Error{Kind: ErrRateLimited, Message: msg, RetryAfter: retryAfter}
+}
+
+func Internal(cause error) error {
+	return &Error{Kind: ErrInternal, Message: "internal error", Err: cause}
+}
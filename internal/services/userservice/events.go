@@ -0,0 +1,86 @@
+package userservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/correlation"
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+)
+
+// EventPolicy configures the events the service emits. Domain events about
+// users and URLs go to DomainTopic, keyed by user id so a consumer sees the
+// changes of one user in order; an empty DomainTopic turns them off.
+type EventPolicy struct {
+	Codec       events.Codec
+	DomainTopic string
+}
+
+// userEvent returns the builder storage calls inside the transaction of the
+// change, or nil when domain events are off.
+func (s *Service) userEvent(ctx context.Context, name string) models.UserEventFunc {
+	if s.domainTopic == "" {
+		return nil
+	}
+	correlationID := eventCorrelationID(ctx)
+	return func(u models.User) (models.OutboxMessage, error) {
+		msg := events.UserEvent{
+			EventID:       events.NewEventID(),
+			Type:          name,
+			OccurredAt:    time.Now().UTC(),
+			CorrelationID: correlationID,
+			UserID:        u.ID,
+			Email:         u.Email,
+			Name:          u.Name,
+			CreatedAt:     u.CreatedAt,
+		}
+		return s.domainMessage(events.Meta{ID: msg.EventID, Type: events.CloudEventType(name), Subject: u.ID, Time: msg.OccurredAt}, u.ID, &msg)
+	}
+}
+
+func (s *Service) urlEvent(ctx context.Context, name string) models.URLEventFunc {
+	if s.domainTopic == "" {
+		return nil
+	}
+	correlationID := eventCorrelationID(ctx)
+	return func(u models.UserURL) (models.OutboxMessage, error) {
+		msg := events.URLEvent{
+			EventID:                events.NewEventID(),
+			Type:                   name,
+			OccurredAt:             time.Now().UTC(),
+			CorrelationID:          correlationID,
+			URLID:                  u.ID,
+			UserID:                 u.UserID,
+			URL:                    u.URL,
+			NormalizedURL:          u.NormalizedURL,
+			PollingIntervalSeconds: u.PollingIntervalSeconds,
+			CronExpr:               u.CronExpr,
+			Timezone:               u.Timezone,
+			Priority:               u.Priority,
+			Paused:                 u.Paused,
+		}
+		return s.domainMessage(events.Meta{ID: msg.EventID, Type: events.CloudEventType(name), Subject: u.ID, Time: msg.OccurredAt}, u.UserID, &msg)
+	}
+}
+
+func (s *Service) domainMessage(meta events.Meta, userID string, msg any) (models.OutboxMessage, error) {
+	payload, headers, err := s.codec.JSON(meta, msg)
+	if err != nil {
+		return models.OutboxMessage{}, err
+	}
+	return models.OutboxMessage{
+		EventID: meta.ID,
+		Topic:   s.domainTopic,
+		Key:     []byte(userID),
+		Payload: payload,
+		Headers: headers,
+	}, nil
+}
+
+func eventCorrelationID(ctx context.Context) string {
+	if id := correlation.FromContext(ctx); id != "" {
+		return id
+	}
+	return correlation.NewID()
+}
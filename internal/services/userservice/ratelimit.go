@@ -0,0 +1,73 @@
+package userservice
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// userLimiter is a per-user token bucket for on-demand parse requests. It is
+// kept in memory, so each instance enforces the limit on its own.
+type userLimiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	mu      sync.Mutex
+	buckets map[string]*userBucket
+}
+
+type userBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newUserLimiter(ratePerMinute float64, burst int) *userLimiter {
+	if ratePerMinute <= 0 {
+		return nil
+	}
+	return &userLimiter{
+		ratePerSecond: ratePerMinute / 60,
+		burst:         float64(max(burst, 1)),
+		buckets:       make(map[string]*userBucket),
+	}
+}
+
+// Allow takes a token for userID. If none is left it returns false and how
+// long to wait for the next one.
+func (l *userLimiter) Allow(userID string, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[userID]
+	if !ok {
+		if len(l.buckets) >= 10000 {
+			l.evictFull(now)
+		}
+		b = &userBucket{tokens: l.burst, updated: now}
+		l.buckets[userID] = b
+	}
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.ratePerSecond)
+		b.updated = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / l.ratePerSecond
+	return false, time.Duration(math.Ceil(wait)) * time.Second
+}
+
+// evictFull drops buckets that have refilled completely; they behave the same
+// as a fresh bucket.
+func (l *userLimiter) evictFull(now time.Time) {
+	for id, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.ratePerSecond >= l.burst {
+			delete(l.buckets, id)
+		}
+	}
+}
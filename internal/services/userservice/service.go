@@ -5,27 +5,77 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
+	"github.com/LehaAlexey/Users/internal/correlation"
 	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/models/events"
+	"github.com/LehaAlexey/Users/internal/scheduler"
+	"github.com/google/uuid"
 )
 
 type Storage interface {
-	CreateUser(ctx context.Context, email string, name string) (*models.User, error)
+	CreateUser(ctx context.Context, email string, name string, emit models.UserEventFunc) (*models.User, error)
 	GetUserByID(ctx context.Context, userID string) (*models.User, error)
-	AddURL(ctx context.Context, userID string, url string, normalizedURL string, intervalSeconds int) (*models.UserURL, error)
-	ListUserURLs(ctx context.Context, userID string, limit int) ([]models.UserURL, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	ListUsers(ctx context.Context, query models.UserListQuery) ([]models.UserSummary, error)
+	UpdateUser(ctx context.Context, userID string, email *string, name *string, emit models.UserEventFunc) (*models.User, error)
+	DeleteUser(ctx context.Context, userID string, emit models.UserEventFunc) error
+	RestoreUser(ctx context.Context, userID string, emit models.UserEventFunc) (*models.User, error)
+	AddURL(ctx context.Context, u models.UserURL, emit models.URLEventFunc) (*models.UserURL, error)
+	ListUserURLs(ctx context.Context, query models.URLListQuery) ([]models.UserURL, error)
+	UpdateURL(ctx context.Context, userID string, urlID string, intervalSeconds int, priority *int, emit models.URLEventFunc) (*models.UserURL, error)
+	PauseURL(ctx context.Context, userID string, urlID string, emit models.URLEventFunc) (*models.UserURL, error)
+	ResumeURL(ctx context.Context, userID string, urlID string, cronNext func(models.UserURL) (time.Time, error), emit models.URLEventFunc) (*models.UserURL, error)
+	DeleteURL(ctx context.Context, userID string, urlID string, emit models.URLEventFunc) error
+	RecordRunResult(ctx context.Context, result models.RunResult, disableAfter int, onDisable func(models.UserURL) (models.OutboxMessage, error)) error
+	TriggerParse(ctx context.Context, userID string, urlID string, build func(models.UserURL) (models.OutboxMessage, error)) error
+	ListPublishFailures(ctx context.Context, afterID int64, limit int) ([]models.PublishFailure, error)
+	ReplayPublishFailure(ctx context.Context, id int64) error
+	DiscardPublishFailure(ctx context.Context, id int64) error
+}
+
+// FailurePolicy controls automatic disabling of failing URLs: DisableAfter
+// consecutive failed parse runs disable a URL, failed publishes do not count.
+// DisableAfter <= 0 turns it off.
+type FailurePolicy struct {
+	DisableAfter  int
+	DisabledTopic string
+}
+
+// TriggerPolicy configures on-demand parse requests: where they are published
+// and how many each user may make. RatePerMinute <= 0 disables the limit.
+type TriggerPolicy struct {
+	Lanes         scheduler.Lanes
+	RatePerMinute float64
+	Burst         int
 }
 
 type Service struct {
-	storage Storage
+	storage                Storage
 	defaultIntervalSeconds int
+	failures               FailurePolicy
+	slots                  scheduler.SlotPolicy
+	lanes                  scheduler.Lanes
+	codec                  events.Codec
+	domainTopic            string
+	triggers               *userLimiter
 }
 
-func New(storage Storage, defaultIntervalSeconds int) *Service {
+func New(storage Storage, defaultIntervalSeconds int, failures FailurePolicy, slots scheduler.SlotPolicy, triggers TriggerPolicy, publishing EventPolicy) *Service {
 	if defaultIntervalSeconds <= 0 {
 		defaultIntervalSeconds = 3600
 	}
-	return &Service{storage: storage, defaultIntervalSeconds: defaultIntervalSeconds}
+	return &Service{
+		storage:                storage,
+		defaultIntervalSeconds: defaultIntervalSeconds,
+		failures:               failures,
+		slots:                  slots,
+		lanes:                  triggers.Lanes,
+		codec:                  publishing.Codec,
+		domainTopic:            publishing.DomainTopic,
+		triggers:               newUserLimiter(triggers.RatePerMinute, triggers.Burst),
+	}
 }
 
 type CreateUserRequest struct {
@@ -37,61 +87,469 @@ func (s *Service) CreateUser(ctx context.Context, req CreateUserRequest) (*model
 	email := strings.TrimSpace(req.Email)
 	name := strings.TrimSpace(req.Name)
 	if email == "" {
-		return nil, fmt.Errorf("email is required")
+		return nil, InvalidArgument("email", "email is required")
 	}
 	if name == "" {
-		return nil, fmt.Errorf("name is required")
+		return nil, InvalidArgument("name", "name is required")
 	}
 
-	return s.storage.CreateUser(ctx, email, name)
+	return s.storage.CreateUser(ctx, email, name, s.userEvent(ctx, events.UserCreated))
 }
 
 func (s *Service) GetUser(ctx context.Context, userID string) (*models.User, error) {
 	id := strings.TrimSpace(userID)
 	if id == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, InvalidArgument("user_id", "user id is required")
 	}
 
 	return s.storage.GetUserByID(ctx, id)
 }
 
-func (s *Service) AddURL(ctx context.Context, userID string, rawURL string, intervalSeconds int) (*models.UserURL, error) {
+func (s *Service) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, InvalidArgument("email", "email is required")
+	}
+
+	return s.storage.GetUserByEmail(ctx, email)
+}
+
+// ListUsersRequest filters the admin user listing. Query matches an email
+// prefix or a name substring. Cursor is the NextCursor of the previous page.
+type ListUsersRequest struct {
+	Limit       int
+	Cursor      string
+	Query       string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+const userListSort = "-created_at"
+
+func (s *Service) ListUsers(ctx context.Context, req ListUsersRequest) (*models.UserPage, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return nil, InvalidArgument("created_to", "must be after created_from")
+	}
+	query := models.UserListQuery{
+		Limit:       limit + 1,
+		Query:       strings.TrimSpace(req.Query),
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+	}
+
+	filters := query.Query + "|" + formatTimeFilter(req.CreatedFrom) + "|" + formatTimeFilter(req.CreatedTo)
+	if cursor := strings.TrimSpace(req.Cursor); cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != userListSort || c.Filters != filters {
+			return nil, InvalidArgument("cursor", "cursor was issued for a different filter")
+		}
+		query.After = &models.ListPosition{Value: c.Value, ID: c.ID}
+	}
+
+	items, err := s.storage.ListUsers(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.UserPage{Users: items}
+	if len(items) > limit {
+		page.Users = items[:limit]
+		last := page.Users[limit-1]
+		page.NextCursor = encodeCursor(pageCursor{
+			Sort:    userListSort,
+			Filters: filters,
+			Value:   last.CreatedAt.UTC().Format(time.RFC3339Nano),
+			ID:      last.ID,
+		})
+	}
+	return page, nil
+}
+
+type UpdateUserRequest struct {
+	Email *string
+	Name  *string
+}
+
+func (s *Service) UpdateUser(ctx context.Context, userID string, req UpdateUserRequest) (*models.User, error) {
+	id := strings.TrimSpace(userID)
+	if id == "" {
+		return nil, InvalidArgument("user_id", "user id is required")
+	}
+	if req.Email == nil && req.Name == nil {
+		return nil, InvalidArgument("", "nothing to update")
+	}
+
+	var email, name *string
+	if req.Email != nil {
+		v := strings.TrimSpace(*req.Email)
+		if v == "" {
+			return nil, InvalidArgument("email", "email must not be empty")
+		}
+		email = &v
+	}
+	if req.Name != nil {
+		v := strings.TrimSpace(*req.Name)
+		if v == "" {
+			return nil, InvalidArgument("name", "name must not be empty")
+		}
+		name = &v
+	}
+
+	return s.storage.UpdateUser(ctx, id, email, name, s.userEvent(ctx, events.UserUpdated))
+}
+
+func (s *Service) DeleteUser(ctx context.Context, userID string) error {
+	id := strings.TrimSpace(userID)
+	if id == "" {
+		return InvalidArgument("user_id", "user id is required")
+	}
+
+	return s.storage.DeleteUser(ctx, id, s.userEvent(ctx, events.UserDeleted))
+}
+
+func (s *Service) RestoreUser(ctx context.Context, userID string) (*models.User, error) {
+	id := strings.TrimSpace(userID)
+	if id == "" {
+		return nil, InvalidArgument("user_id", "user id is required")
+	}
+
+	return s.storage.RestoreUser(ctx, id, s.userEvent(ctx, events.UserRestored))
+}
+
+type AddURLRequest struct {
+	URL                    string
+	PollingIntervalSeconds int
+	CronExpr               string
+	Timezone               string
+	Priority               int
+}
+
+func (s *Service) AddURL(ctx context.Context, userID string, req AddURLRequest) (*models.UserURL, error) {
 	id := strings.TrimSpace(userID)
 	if id == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, InvalidArgument("user_id", "user id is required")
 	}
-	u, err := NormalizeURL(rawURL)
+	u, err := NormalizeURL(req.URL)
 	if err != nil {
 		return nil, err
 	}
 
+	intervalSeconds := req.PollingIntervalSeconds
 	if intervalSeconds <= 0 {
 		intervalSeconds = s.defaultIntervalSeconds
 	}
-	return s.storage.AddURL(ctx, id, rawURL, u, intervalSeconds)
+
+	cronExpr := strings.TrimSpace(req.CronExpr)
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone != "" {
+		if cronExpr == "" {
+			return nil, InvalidArgument("timezone", "timezone requires a cron expression")
+		}
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, InvalidArgument("timezone", "unknown timezone")
+		}
+	}
+	if err := validatePriority(req.Priority); err != nil {
+		return nil, err
+	}
+	schedule, err := scheduler.ParseSchedule(cronExpr, timezone, intervalSeconds)
+	if err != nil {
+		return nil, InvalidArgument("cron_expr", err.Error())
+	}
+
+	// The id is chosen here so the first run gets the same per-URL phase the
+	// scheduler applies to every later one.
+	urlID := uuid.Must(uuid.NewV7()).String()
+	nextRunAt := s.slots.FirstRun(schedule, urlID, time.Now().UTC())
+
+	// The first run of a new URL is triggered by this call, so its event
+	// carries the caller's correlation id.
+	return s.storage.AddURL(ctx, models.UserURL{
+		ID:                     urlID,
+		UserID:                 id,
+		URL:                    req.URL,
+		NormalizedURL:          u,
+		PollingIntervalSeconds: intervalSeconds,
+		CronExpr:               cronExpr,
+		Timezone:               timezone,
+		NextRunAt:              nextRunAt,
+		CorrelationID:          correlation.FromContext(ctx),
+		Priority:               req.Priority,
+	}, s.urlEvent(ctx, events.URLAdded))
 }
 
-func (s *Service) ListUserURLs(ctx context.Context, userID string, limit int) ([]models.UserURL, error) {
+// ListURLsRequest filters and orders a user's URLs. Sort is one of
+// created_at, next_run_at or url, with a leading "-" for descending order; the
+// default is -created_at. Cursor is the NextCursor of the previous page.
+type ListURLsRequest struct {
+	Limit  int
+	Cursor string
+	Host   string
+	Status string
+	Search string
+	Sort   string
+}
+
+func (s *Service) ListUserURLs(ctx context.Context, userID string, req ListURLsRequest) (*models.URLPage, error) {
 	id := strings.TrimSpace(userID)
 	if id == "" {
-		return nil, fmt.Errorf("user id is required")
+		return nil, InvalidArgument("user_id", "user id is required")
 	}
+	limit := req.Limit
 	if limit <= 0 || limit > 500 {
 		limit = 100
 	}
 
-	return s.storage.ListUserURLs(ctx, id, limit)
+	sort := strings.TrimSpace(req.Sort)
+	if sort == "" {
+		sort = "-" + models.URLSortCreatedAt
+	}
+	query := models.URLListQuery{
+		UserID: id,
+		Limit:  limit + 1,
+		Host:   strings.ToLower(strings.TrimSpace(req.Host)),
+		Status: strings.TrimSpace(req.Status),
+		Search: strings.TrimSpace(req.Search),
+		Sort:   strings.TrimPrefix(sort, "-"),
+		Desc:   strings.HasPrefix(sort, "-"),
+	}
+	switch query.Sort {
+	case models.URLSortCreatedAt, models.URLSortNextRunAt, models.URLSortURL:
+	default:
+		return nil, InvalidArgument("sort", "must be one of created_at, next_run_at, url, optionally prefixed with -")
+	}
+	switch query.Status {
+	case "", models.URLStatusActive, models.URLStatusPaused, models.URLStatusDisabled:
+	default:
+		return nil, InvalidArgument("status", "must be one of active, paused, disabled")
+	}
+
+	filters := query.Host + "|" + query.Status + "|" + query.Search
+	if cursor := strings.TrimSpace(req.Cursor); cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != sort || c.Filters != filters {
+			return nil, InvalidArgument("cursor", "cursor was issued for a different sort or filter")
+		}
+		query.After = &models.ListPosition{Value: c.Value, ID: c.ID}
+	}
+
+	items, err := s.storage.ListUserURLs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	page := &models.URLPage{URLs: items}
+	if len(items) > limit {
+		page.URLs = items[:limit]
+		last := page.URLs[limit-1]
+		page.NextCursor = encodeCursor(pageCursor{
+			Sort:    sort,
+			Filters: filters,
+			Value:   urlSortValue(last, query.Sort),
+			ID:      last.ID,
+		})
+	}
+	return page, nil
+}
+
+// UpdateURLRequest changes the polling interval and/or the priority. A zero
+// PollingIntervalSeconds keeps the current schedule.
+type UpdateURLRequest struct {
+	PollingIntervalSeconds int
+	Priority               *int
+}
+
+func (s *Service) UpdateURL(ctx context.Context, userID string, urlID string, req UpdateURLRequest) (*models.UserURL, error) {
+	uid, id, err := urlIDs(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+	if req.PollingIntervalSeconds == 0 && req.Priority == nil {
+		return nil, InvalidArgument("", "nothing to update")
+	}
+	if req.PollingIntervalSeconds < 0 {
+		return nil, InvalidArgument("polling_interval_seconds", "polling interval must be positive")
+	}
+	if req.Priority != nil {
+		if err := validatePriority(*req.Priority); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.storage.UpdateURL(ctx, uid, id, req.PollingIntervalSeconds, req.Priority, s.urlEvent(ctx, events.URLUpdated))
+}
+
+func (s *Service) PauseURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error) {
+	uid, id, err := urlIDs(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.PauseURL(ctx, uid, id, s.urlEvent(ctx, events.URLUpdated))
+}
+
+func (s *Service) ResumeURL(ctx context.Context, userID string, urlID string) (*models.UserURL, error) {
+	uid, id, err := urlIDs(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.ResumeURL(ctx, uid, id, s.cronNext, s.urlEvent(ctx, events.URLUpdated))
+}
+
+// cronNext returns the first slot of a cron URL from now on, as AddURL does
+// for a new one.
+func (s *Service) cronNext(u models.UserURL) (time.Time, error) {
+	schedule, err := scheduler.ParseSchedule(u.CronExpr, u.Timezone, u.PollingIntervalSeconds)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule of url %s: %w", u.ID, err)
+	}
+	return s.slots.FirstRun(schedule, u.ID, time.Now().UTC()), nil
+}
+
+func (s *Service) DeleteURL(ctx context.Context, userID string, urlID string) error {
+	uid, id, err := urlIDs(userID, urlID)
+	if err != nil {
+		return err
+	}
+
+	return s.storage.DeleteURL(ctx, uid, id, s.urlEvent(ctx, events.URLRemoved))
+}
+
+// TriggerParse publishes a top priority ParseRequested for the URL right away,
+// independently of its schedule. The returned correlation id is the caller's
+// request id when there is one. Paused and disabled URLs are rejected with
+// ErrConflict.
+func (s *Service) TriggerParse(ctx context.Context, userID string, urlID string) (*models.ParseTrigger, error) {
+	uid, id, err := urlIDs(userID, urlID)
+	if err != nil {
+		return nil, err
+	}
+	trigger := models.ParseTrigger{
+		URLID:         id,
+		CorrelationID: correlation.FromContext(ctx),
+		RequestedAt:   time.Now().UTC(),
+	}
+	if trigger.CorrelationID == "" {
+		trigger.CorrelationID = correlation.NewID()
+	}
+	// The rate limit token is taken only once the URL is known to belong to
+	// the user and to be active, so rejected triggers do not use it up.
+	build := func(u models.UserURL) (models.OutboxMessage, error) {
+		switch {
+		case u.DisabledAt != nil:
+			return models.OutboxMessage{}, Conflict("url is disabled; resume it first")
+		case u.Paused:
+			return models.OutboxMessage{}, Conflict("url is paused; resume it first")
+		}
+		if ok, retryAfter := s.triggers.Allow(uid, time.Now()); !ok {
+			return models.OutboxMessage{}, RateLimited("too many parse requests", retryAfter)
+		}
+		u.Priority = models.PriorityMax
+		event, err := scheduler.ParseRequest(u, trigger.CorrelationID, trigger.RequestedAt, s.lanes.TopicFor(u.Priority), s.codec)
+		trigger.EventID = event.EventID
+		return event, err
+	}
+	if err := s.storage.TriggerParse(ctx, uid, id, build); err != nil {
+		return nil, err
+	}
+	return &trigger, nil
+}
+
+// RecordRunResult stores the outcome of a parse run reported by the parser
+// (via Kafka or the ingest RPC) or of a failed publish of its request.
+func (s *Service) RecordRunResult(ctx context.Context, result models.RunResult) error {
+	result.URLID = strings.TrimSpace(result.URLID)
+	if result.URLID == "" {
+		return InvalidArgument("url_id", "url id is required")
+	}
+	switch result.Status {
+	case models.RunStatusSucceeded, models.RunStatusFailed, models.RunStatusPublishFailed:
+	default:
+		return InvalidArgument("status", "unknown run status")
+	}
+	if result.EventID == "" {
+		result.EventID = events.NewEventID()
+	}
+	if result.FinishedAt.IsZero() {
+		result.FinishedAt = time.Now().UTC()
+	}
+	if result.CorrelationID == "" {
+		result.CorrelationID = correlation.FromContext(ctx)
+	}
+
+	onDisable := func(u models.UserURL) (models.OutboxMessage, error) {
+		return s.urlDisabledEvent(u, result)
+	}
+	return s.storage.RecordRunResult(ctx, result, s.failures.DisableAfter, onDisable)
+}
+
+func (s *Service) urlDisabledEvent(u models.UserURL, result models.RunResult) (models.OutboxMessage, error) {
+	correlationID := result.CorrelationID
+	if correlationID == "" {
+		correlationID = correlation.NewID()
+	}
+	msg := events.URLDisabled{
+		EventID:             events.NewEventID(),
+		OccurredAt:          time.Now().UTC(),
+		CorrelationID:       correlationID,
+		URLID:               u.ID,
+		UserID:              u.UserID,
+		URL:                 u.URL,
+		Reason:              u.DisabledReason,
+		LastError:           u.LastError,
+		ConsecutiveFailures: u.ConsecutiveFailures,
+	}
+	meta := events.Meta{ID: msg.EventID, Type: events.TypeURLDisabled, Subject: u.ID, Time: msg.OccurredAt}
+	payload, headers, err := s.codec.JSON(meta, &msg)
+	if err != nil {
+		return models.OutboxMessage{}, err
+	}
+	return models.OutboxMessage{
+		EventID: msg.EventID,
+		Topic:   s.failures.DisabledTopic,
+		Key:     []byte(u.UserID),
+		Payload: payload,
+		Headers: headers,
+	}, nil
+}
+
+func validatePriority(priority int) error {
+	if priority < models.PriorityNormal || priority > models.PriorityMax {
+		return InvalidArgument("priority", fmt.Sprintf("priority must be between %d and %d", models.PriorityNormal, models.PriorityMax))
+	}
+	return nil
+}
+
+func urlIDs(userID string, urlID string) (string, string, error) {
+	uid := strings.TrimSpace(userID)
+	if uid == "" {
+		return "", "", InvalidArgument("user_id", "user id is required")
+	}
+	id := strings.TrimSpace(urlID)
+	if id == "" {
+		return "", "", InvalidArgument("url_id", "url id is required")
+	}
+	return uid, id, nil
 }
 
 func NormalizeURL(rawURL string) (string, error) {
 	clean := strings.TrimSpace(rawURL)
 	if clean == "" {
-		return "", fmt.Errorf("url is required")
+		return "", InvalidArgument("url", "url is required")
 	}
 
 	parsed, err := url.Parse(clean)
 	if err != nil {
-		return "", fmt.Errorf("invalid url: %w", err)
+		return "", InvalidArgument("url", "invalid url: "+err.Error())
 	}
 	if parsed.Scheme == "" {
 		parsed.Scheme = "https"
@@ -100,7 +558,7 @@ func NormalizeURL(rawURL string) (string, error) {
 
 	host := strings.ToLower(parsed.Hostname())
 	if host == "" {
-		return "", fmt.Errorf("invalid url host")
+		return "", InvalidArgument("url", "invalid url host")
 	}
 
 	path := strings.TrimRight(parsed.EscapedPath(), "/")
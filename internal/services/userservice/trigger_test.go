@@ -0,0 +1,82 @@
+package userservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/scheduler"
+)
+
+const (
+	testUserID = "0190a5f0-0000-7000-8000-000000000001"
+	testURLID  = "0190a5f0-0000-7000-8000-000000000002"
+)
+
+// triggerStorage serves TriggerParse from a fixed set of URLs; the rest of
+// Storage is not used by these tests.
+type triggerStorage struct {
+	Storage
+	urls map[string]models.UserURL
+}
+
+func (s triggerStorage) TriggerParse(_ context.Context, userID string, urlID string, build func(models.UserURL) (models.OutboxMessage, error)) error {
+	u, ok := s.urls[urlID]
+	if !ok || u.UserID != userID {
+		return NotFound("url not found", nil)
+	}
+	_, err := build(u)
+	return err
+}
+
+func newTriggerService(urls ...models.UserURL) *Service {
+	storage := triggerStorage{urls: make(map[string]models.UserURL)}
+	for _, u := range urls {
+		storage.urls[u.ID] = u
+	}
+	triggers := TriggerPolicy{Lanes: scheduler.Lanes{Regular: "parse_requested"}, RatePerMinute: 1, Burst: 1}
+	return New(storage, 3600, FailurePolicy{}, scheduler.SlotPolicy{}, triggers, EventPolicy{})
+}
+
+func TestTriggerParseRejectsInactiveURLs(t *testing.T) {
+	disabledAt := time.Now()
+	tests := []struct {
+		name string
+		url  models.UserURL
+	}{
+		{"paused", models.UserURL{Paused: true}},
+		{"disabled", models.UserURL{Paused: true, DisabledAt: &disabledAt}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := tt.url
+			u.ID, u.UserID, u.NormalizedURL = testURLID, testUserID, "https://shop.example/"
+			s := newTriggerService(u)
+
+			if _, err := s.TriggerParse(context.Background(), testUserID, testURLID); !errors.Is(err, ErrConflict) {
+				t.Fatalf("TriggerParse: got %v, want conflict", err)
+			}
+		})
+	}
+}
+
+func TestTriggerParseTakesTokenOnlyForAcceptedTriggers(t *testing.T) {
+	active := models.UserURL{ID: testURLID, UserID: testUserID, NormalizedURL: "https://shop.example/", PollingIntervalSeconds: 3600}
+	s := newTriggerService(active)
+	ctx := context.Background()
+
+	// Unknown URLs do not use up the user's single token.
+	for i := 0; i < 3; i++ {
+		if _, err := s.TriggerParse(ctx, testUserID, "0190a5f0-0000-7000-8000-0000000000ff"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("unknown url: got %v, want not found", err)
+		}
+	}
+	if _, err := s.TriggerParse(ctx, testUserID, testURLID); err != nil {
+		t.Fatalf("first trigger: %v", err)
+	}
+	if _, err := s.TriggerParse(ctx, testUserID, testURLID); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("second trigger: got %v, want rate limited", err)
+	}
+}
@@ -0,0 +1,109 @@
+package pgstorage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/testdb"
+)
+
+func TestClaimDueURLsHeavyUserDoesNotDelaySmallOne(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+
+	heavy := testdb.CreateUser(t, pool, "heavy@example.com")
+	small := testdb.CreateUser(t, pool, "small@example.com")
+	// The heavy user's backlog is older than anything the small user has.
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 50; i++ {
+		testdb.AddURL(t, pool, heavy, fmt.Sprintf("https://heavy.example/%d", i), base.Add(time.Duration(i)*time.Second))
+	}
+	smallURL := testdb.AddURL(t, pool, small, "https://small.example/", time.Now().Add(-time.Minute))
+
+	fairness := models.FairnessPolicy{Default: models.PlanQuota{MaxPerBatch: 5, Weight: 1}}
+	urls, err := s.ClaimDueURLs(context.Background(), 10, time.Minute, fairness)
+	if err != nil {
+		t.Fatalf("ClaimDueURLs: %v", err)
+	}
+
+	var heavyClaimed int
+	smallClaimed := false
+	for _, u := range urls {
+		switch u.UserID {
+		case heavy:
+			heavyClaimed++
+		case small:
+			smallClaimed = u.ID == smallURL
+		}
+	}
+	if !smallClaimed {
+		t.Fatalf("small user's URL not in the first batch of %d", len(urls))
+	}
+	if heavyClaimed > 5 {
+		t.Fatalf("heavy user got %d URLs, want at most MaxPerBatch 5", heavyClaimed)
+	}
+}
+
+func TestClaimDueURLsHeavyBacklogDoesNotHideOtherUsers(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+
+	const limit = 10
+	heavy := testdb.CreateUser(t, pool, "heavy@example.com")
+	// Far more due rows than any global window of limit-sized batches, all
+	// older than the other users' URLs.
+	base := time.Now().Add(-2 * time.Hour)
+	for i := 0; i < 30*limit; i++ {
+		testdb.AddURL(t, pool, heavy, fmt.Sprintf("https://heavy.example/%d", i), base.Add(time.Duration(i)*time.Second))
+	}
+	others := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		user := testdb.CreateUser(t, pool, fmt.Sprintf("small%d@example.com", i))
+		testdb.AddURL(t, pool, user, "https://small.example/", time.Now().Add(-time.Minute))
+		others[user] = false
+	}
+
+	fairness := models.FairnessPolicy{Default: models.PlanQuota{MaxPerBatch: 5, Weight: 1}}
+	urls, err := s.ClaimDueURLs(context.Background(), limit, time.Minute, fairness)
+	if err != nil {
+		t.Fatalf("ClaimDueURLs: %v", err)
+	}
+	for _, u := range urls {
+		if _, ok := others[u.UserID]; ok {
+			others[u.UserID] = true
+		}
+	}
+	for user, claimed := range others {
+		if !claimed {
+			t.Fatalf("user %s not claimed behind a %d-row backlog; batch of %d", user, 30*limit, len(urls))
+		}
+	}
+}
+
+func TestClaimDueURLsSkipsClaimedRows(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+
+	user := testdb.CreateUser(t, pool, "user@example.com")
+	for i := 0; i < 5; i++ {
+		testdb.AddURL(t, pool, user, fmt.Sprintf("https://shop.example/%d", i), time.Now().Add(-time.Minute))
+	}
+
+	first, err := s.ClaimDueURLs(context.Background(), 10, time.Minute, models.FairnessPolicy{})
+	if err != nil {
+		t.Fatalf("ClaimDueURLs: %v", err)
+	}
+	if len(first) != 5 {
+		t.Fatalf("first claim got %d URLs, want 5", len(first))
+	}
+	second, err := s.ClaimDueURLs(context.Background(), 10, time.Minute, models.FairnessPolicy{})
+	if err != nil {
+		t.Fatalf("ClaimDueURLs: %v", err)
+	}
+	if len(second) != 0 {
+		t.Fatalf("second claim got %d leased URLs, want 0", len(second))
+	}
+}
@@ -0,0 +1,58 @@
+package pgstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/testdb"
+)
+
+func TestRescheduleDropsHostDeferral(t *testing.T) {
+	tests := []struct {
+		name       string
+		reschedule func(s *Storage, userID, urlID string) error
+	}{
+		{"pause and resume", func(s *Storage, userID, urlID string) error {
+			if _, err := s.PauseURL(context.Background(), userID, urlID, nil); err != nil {
+				return err
+			}
+			_, err := s.ResumeURL(context.Background(), userID, urlID, nil, nil)
+			return err
+		}},
+		{"new interval", func(s *Storage, userID, urlID string) error {
+			_, err := s.UpdateURL(context.Background(), userID, urlID, 600, nil, nil)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := testdb.New(t)
+			s := New(pool)
+			ctx := context.Background()
+
+			user := testdb.CreateUser(t, pool, "user@example.com")
+			urlID := testdb.AddURL(t, pool, user, "https://shop.example/", time.Now().Add(-time.Minute))
+			claimed, err := s.ClaimDueURLs(ctx, 1, time.Minute, models.FairnessPolicy{})
+			if err != nil || len(claimed) != 1 {
+				t.Fatalf("ClaimDueURLs: %v, %d urls", err, len(claimed))
+			}
+			run := models.DeferredRun{URLID: urlID, ClaimID: claimed[0].ClaimID, Until: time.Now().Add(time.Hour)}
+			if err := s.DeferURLs(ctx, []models.DeferredRun{run}); err != nil {
+				t.Fatalf("DeferURLs: %v", err)
+			}
+
+			if err := tt.reschedule(s, user, urlID); err != nil {
+				t.Fatalf("reschedule: %v", err)
+			}
+			var deferred bool
+			if err := pool.QueryRow(ctx, `SELECT deferred_until IS NOT NULL FROM user_urls WHERE id = $1`, urlID).Scan(&deferred); err != nil {
+				t.Fatalf("read url: %v", err)
+			}
+			if deferred {
+				t.Fatal("deferred_until survived the reschedule; the URL would skip the host limiter")
+			}
+		})
+	}
+}
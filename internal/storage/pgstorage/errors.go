@@ -0,0 +1,49 @@
+package pgstorage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/LehaAlexey/Users/internal/services/userservice"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	codeUniqueViolation     = "23505"
+	codeInvalidTextRepr     = "22P02"
+	codeForeignKeyViolation = "23503"
+)
+
+// wrapError translates pgx/Postgres failures into userservice domain errors.
+// entity names the row the operation was looking for ("user", "url"). Domain
+// errors returned by service callbacks pass through unchanged.
+func wrapError(op string, entity string, err error) error {
+	var pgErr *pgconn.PgError
+	var svcErr *userservice.Error
+	switch {
+	case errors.As(err, &svcErr):
+	case errors.Is(err, pgx.ErrNoRows):
+		err = userservice.NotFound(entity+" not found", err)
+	case errors.As(err, &pgErr) && pgErr.Code == codeUniqueViolation:
+		err = userservice.AlreadyExists(uniqueViolationMessage(pgErr.ConstraintName), err)
+	case errors.As(err, &pgErr) && pgErr.Code == codeForeignKeyViolation:
+		err = userservice.NotFound(entity+" not found", err)
+	case errors.As(err, &pgErr) && pgErr.Code == codeInvalidTextRepr:
+		err = userservice.InvalidArgument("id", "invalid id format")
+	default:
+		err = userservice.Internal(err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func uniqueViolationMessage(constraint string) string {
+	switch constraint {
+	case "users_email_ux":
+		return "user with this email already exists"
+	case "user_urls_user_norm_ux":
+		return "url is already tracked"
+	default:
+		return "already exists"
+	}
+}
@@ -0,0 +1,47 @@
+package pgstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/testdb"
+)
+
+func TestListUserURLsStatusFiltersAreDistinct(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	user := testdb.CreateUser(t, pool, "user@example.com")
+	active := testdb.AddURL(t, pool, user, "https://shop.example/active", time.Now())
+	paused := testdb.AddURL(t, pool, user, "https://shop.example/paused", time.Now())
+	disabled := testdb.AddURL(t, pool, user, "https://shop.example/disabled", time.Now())
+
+	if _, err := s.PauseURL(ctx, user, paused, nil); err != nil {
+		t.Fatalf("PauseURL: %v", err)
+	}
+	failed := models.RunResult{URLID: disabled, EventID: "result-1", Status: models.RunStatusFailed, FinishedAt: time.Now()}
+	if err := s.RecordRunResult(ctx, failed, 1, nil); err != nil {
+		t.Fatalf("RecordRunResult: %v", err)
+	}
+
+	for status, want := range map[string]string{
+		models.URLStatusActive:   active,
+		models.URLStatusPaused:   paused,
+		models.URLStatusDisabled: disabled,
+	} {
+		urls, err := s.ListUserURLs(ctx, models.URLListQuery{UserID: user, Limit: 10, Status: status, Sort: models.URLSortCreatedAt})
+		if err != nil {
+			t.Fatalf("ListUserURLs(%s): %v", status, err)
+		}
+		if len(urls) != 1 || urls[0].ID != want {
+			ids := make([]string, len(urls))
+			for i, u := range urls {
+				ids[i] = u.ID
+			}
+			t.Fatalf("status %s listed %v, want only %s", status, ids, want)
+		}
+	}
+}
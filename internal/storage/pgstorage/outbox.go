@@ -0,0 +1,140 @@
+package pgstorage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+func insertOutbox(ctx context.Context, tx pgx.Tx, msg models.OutboxMessage) error {
+	const q = `
+		INSERT INTO outbox (event_id, topic, message_key, payload, url_id, headers)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::jsonb)
+		ON CONFLICT (event_id) DO NOTHING;
+	`
+	headers, err := encodeHeaders(msg.Headers)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, q, msg.EventID, msg.Topic, msg.Key, msg.Payload, msg.URLID, headers)
+	return err
+}
+
+// ClaimOutbox picks pending messages and hides them from other relays for the
+// lease duration. Messages that are neither sent nor failed before the lease
+// expires become visible again.
+//
+// Only the oldest unsent message of each topic and key is claimable, so
+// messages sharing a key are published one after another in id order, also
+// when an earlier one is waiting for a retry. A key whose earlier message was
+// moved to publish_failures stays held until that failure is replayed or
+// discarded.
+func (s *Storage) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	const q = `
+		UPDATE outbox
+		SET next_attempt_at = now() + ($2 || ' milliseconds')::interval
+		WHERE id IN (
+			SELECT o.id
+			FROM outbox o
+			WHERE o.sent_at IS NULL AND o.next_attempt_at <= now()
+				AND (o.message_key IS NULL OR NOT EXISTS (
+					SELECT 1
+					FROM outbox e
+					WHERE e.topic = o.topic AND e.message_key = o.message_key
+						AND e.sent_at IS NULL AND e.id < o.id
+				) AND NOT EXISTS (
+					SELECT 1
+					FROM publish_failures f
+					WHERE f.topic = o.topic AND f.message_key = o.message_key
+						AND COALESCE(f.outbox_id, 0) < o.id
+				))
+			ORDER BY o.id ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, COALESCE(url_id::text, ''), topic, message_key, payload, headers, attempts, created_at;
+	`
+	rows, err := s.pool.Query(ctx, q, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, wrapError("claim outbox", "outbox message", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.OutboxMessage, 0, limit)
+	for rows.Next() {
+		var m models.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.EventID, &m.URLID, &m.Topic, &m.Key, &m.Payload, &m.Headers, &m.Attempts, &m.CreatedAt); err != nil {
+			return nil, wrapError("scan outbox", "outbox message", err)
+		}
+		result = append(result, m)
+	}
+	if rows.Err() != nil {
+		return nil, wrapError("claim outbox", "outbox message", rows.Err())
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (s *Storage) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	const q = `
+		UPDATE outbox
+		SET sent_at = now(), last_error = NULL
+		WHERE id = ANY($1);
+	`
+	if _, err := s.pool.Exec(ctx, q, ids); err != nil {
+		return wrapError("mark outbox sent", "outbox message", err)
+	}
+	return nil
+}
+
+func (s *Storage) MarkOutboxFailed(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	const q = `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+			last_error = $2,
+			next_attempt_at = $3
+		WHERE id = $1;
+	`
+	if _, err := s.pool.Exec(ctx, q, id, lastError, retryAt); err != nil {
+		return wrapError("mark outbox failed", "outbox message", err)
+	}
+	return nil
+}
+
+func (s *Storage) DeleteSentOutbox(ctx context.Context, retentionSeconds int, limit int) (int64, error) {
+	const q = `
+		DELETE FROM outbox
+		WHERE id IN (
+			SELECT id
+			FROM outbox
+			WHERE sent_at IS NOT NULL
+				AND sent_at <= now() - ($1 || ' seconds')::interval
+			ORDER BY sent_at ASC
+			LIMIT $2
+		);
+	`
+	tag, err := s.pool.Exec(ctx, q, retentionSeconds, limit)
+	if err != nil {
+		return 0, wrapError("delete sent outbox", "outbox message", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// encodeHeaders returns headers as a JSON string, empty when there are none,
+// so it can be passed as text and stored as NULL jsonb.
+func encodeHeaders(headers map[string]string) (string, error) {
+	if len(headers) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(headers)
+	if err != nil {
+		return "", fmt.Errorf("marshal headers: %w", err)
+	}
+	return string(b), nil
+}
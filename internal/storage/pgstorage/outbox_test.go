@@ -0,0 +1,116 @@
+package pgstorage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/testdb"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestClaimOutboxKeepsKeyOrderAcrossRetries(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		for i := 0; i < 3; i++ {
+			msg := models.OutboxMessage{EventID: fmt.Sprintf("e%d", i), Topic: "user_events", Key: []byte("user-1"), Payload: []byte("{}")}
+			if err := insertOutbox(ctx, tx, msg); err != nil {
+				return err
+			}
+		}
+		return insertOutbox(ctx, tx, models.OutboxMessage{EventID: "other", Topic: "user_events", Key: []byte("user-2"), Payload: []byte("{}")})
+	})
+	if err != nil {
+		t.Fatalf("insert outbox: %v", err)
+	}
+
+	claimed, err := s.ClaimOutbox(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(claimed) != 2 || claimed[0].EventID != "e0" || claimed[1].EventID != "other" {
+		t.Fatalf("claimed %v, want the head of each key: e0 and other", eventIDs(claimed))
+	}
+
+	// e0 fails and waits for a retry: e1 must not overtake it.
+	if err := s.MarkOutboxFailed(ctx, claimed[0].ID, "broker down", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("MarkOutboxFailed: %v", err)
+	}
+	if err := s.MarkOutboxSent(ctx, []int64{claimed[1].ID}); err != nil {
+		t.Fatalf("MarkOutboxSent: %v", err)
+	}
+	claimed, err = s.ClaimOutbox(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("claimed %v while e0 is waiting for a retry, want nothing", eventIDs(claimed))
+	}
+}
+
+func TestClaimOutboxHoldsKeyBehindPublishFailure(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		for i := 0; i < 2; i++ {
+			msg := models.OutboxMessage{EventID: fmt.Sprintf("e%d", i), Topic: "user_events", Key: []byte("user-1"), Payload: []byte("{}")}
+			if err := insertOutbox(ctx, tx, msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("insert outbox: %v", err)
+	}
+
+	claimed, err := s.ClaimOutbox(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].EventID != "e0" {
+		t.Fatalf("claimed %v, want e0", eventIDs(claimed))
+	}
+	if err := s.MarkOutboxDead(ctx, claimed[0].ID, "broker down"); err != nil {
+		t.Fatalf("MarkOutboxDead: %v", err)
+	}
+
+	// e1 waits for the dead-lettered e0 instead of overtaking it.
+	claimed, err = s.ClaimOutbox(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(claimed) != 0 {
+		t.Fatalf("claimed %v while e0 is dead-lettered, want nothing", eventIDs(claimed))
+	}
+
+	failures, err := s.ListPublishFailures(ctx, 0, 10)
+	if err != nil || len(failures) != 1 {
+		t.Fatalf("ListPublishFailures: %v, %d failures", err, len(failures))
+	}
+	if err := s.ReplayPublishFailure(ctx, failures[0].ID); err != nil {
+		t.Fatalf("ReplayPublishFailure: %v", err)
+	}
+	claimed, err = s.ClaimOutbox(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutbox: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].EventID != "e0" {
+		t.Fatalf("claimed %v after replay, want e0 ahead of e1", eventIDs(claimed))
+	}
+}
+
+func eventIDs(msgs []models.OutboxMessage) []string {
+	ids := make([]string, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.EventID
+	}
+	return ids
+}
@@ -2,9 +2,15 @@ package pgstorage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -16,16 +22,24 @@ func New(pool *pgxpool.Pool) *Storage {
 	return &Storage{pool: pool}
 }
 
-func (s *Storage) CreateUser(ctx context.Context, email string, name string) (*models.User, error) {
+// CreateUser, UpdateUser, DeleteUser and the URL mutations below queue the
+// event built by emit in the same transaction as the change, so an event is
+// stored exactly when the change commits. A nil emit queues nothing.
+func (s *Storage) CreateUser(ctx context.Context, email string, name string, emit models.UserEventFunc) (*models.User, error) {
 	const q = `
 		INSERT INTO users (email, name)
 		VALUES ($1, $2)
 		RETURNING id, email, name, created_at;
 	`
-	row := s.pool.QueryRow(ctx, q, email, name)
 	var u models.User
-	if err := row.Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
-		return nil, fmt.Errorf("create user: %w", err)
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, q, email, name).Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
+			return err
+		}
+		return queueUserEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("create user", "user", err)
 	}
 	return &u, nil
 }
@@ -34,96 +48,731 @@ func (s *Storage) GetUserByID(ctx context.Context, userID string) (*models.User,
 	const q = `
 		SELECT id, email, name, created_at
 		FROM users
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`
 	row := s.pool.QueryRow(ctx, q, userID)
 	var u models.User
 	if err := row.Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
-		return nil, fmt.Errorf("get user: %w", err)
+		return nil, wrapError("get user", "user", err)
 	}
 	return &u, nil
 }
 
-func (s *Storage) AddURL(ctx context.Context, userID string, url string, normalizedURL string, intervalSeconds int) (*models.UserURL, error) {
+// GetUserByEmail matches email case-insensitively, as users_email_ux does.
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	const q = `
-		INSERT INTO user_urls (user_id, url, normalized_url, polling_interval_seconds, next_run_at)
-		VALUES ($1, $2, $3, $4, now())
-		RETURNING id, user_id, url, normalized_url, polling_interval_seconds, created_at;
+		SELECT id, email, name, created_at
+		FROM users
+		WHERE lower(email) = lower($1) AND deleted_at IS NULL;
 	`
-	row := s.pool.QueryRow(ctx, q, userID, url, normalizedURL, intervalSeconds)
-	var u models.UserURL
-	if err := row.Scan(&u.ID, &u.UserID, &u.URL, &u.NormalizedURL, &u.PollingIntervalSeconds, &u.CreatedAt); err != nil {
-		return nil, fmt.Errorf("add url: %w", err)
+	row := s.pool.QueryRow(ctx, q, email)
+	var u models.User
+	if err := row.Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
+		return nil, wrapError("get user by email", "user", err)
+	}
+	return &u, nil
+}
+
+// ListUsers returns one page of active users with their URL counts, ordered
+// by created_at and ID, newest first.
+func (s *Storage) ListUsers(ctx context.Context, query models.UserListQuery) ([]models.UserSummary, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"u.deleted_at IS NULL"}
+	if query.Query != "" {
+		pattern := escapeLike(strings.ToLower(query.Query))
+		where = append(where, "(lower(u.email) LIKE "+arg(pattern+"%")+" OR u.name ILIKE "+arg("%"+pattern+"%")+")")
+	}
+	if query.CreatedFrom != nil {
+		where = append(where, "u.created_at >= "+arg(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		where = append(where, "u.created_at < "+arg(*query.CreatedTo))
+	}
+	if query.After != nil {
+		where = append(where, "(u.created_at, u.id) < ("+arg(query.After.Value)+"::timestamptz, "+arg(query.After.ID)+"::uuid)")
+	}
+
+	q := `
+		SELECT u.id, u.email, u.name, u.created_at,
+			(SELECT count(*) FROM user_urls uu WHERE uu.user_id = u.id)
+		FROM users u
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY u.created_at DESC, u.id DESC
+		LIMIT ` + arg(query.Limit) + `;
+	`
+	rows, err := s.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, wrapError("list users", "user", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.UserSummary, 0, 16)
+	for rows.Next() {
+		var u models.UserSummary
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt, &u.URLCount); err != nil {
+			return nil, wrapError("scan user", "user", err)
+		}
+		result = append(result, u)
+	}
+	if rows.Err() != nil {
+		return nil, wrapError("list users", "user", rows.Err())
+	}
+
+	return result, nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, userID string, email *string, name *string, emit models.UserEventFunc) (*models.User, error) {
+	const q = `
+		UPDATE users
+		SET email = COALESCE($2, email),
+			name = COALESCE($3, name)
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, email, name, created_at;
+	`
+	var u models.User
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, q, userID, email, name).Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
+			return err
+		}
+		return queueUserEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("update user", "user", err)
+	}
+	return &u, nil
+}
+
+func (s *Storage) DeleteUser(ctx context.Context, userID string, emit models.UserEventFunc) error {
+	const q = `
+		UPDATE users
+		SET deleted_at = now()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, email, name, created_at;
+	`
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		var u models.User
+		if err := tx.QueryRow(ctx, q, userID).Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
+			return err
+		}
+		return queueUserEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return wrapError("delete user", "user", err)
+	}
+	return nil
+}
+
+// RestoreUser fails with AlreadyExists when another active user has taken the
+// email in the meantime (users_email_ux only covers active users).
+func (s *Storage) RestoreUser(ctx context.Context, userID string, emit models.UserEventFunc) (*models.User, error) {
+	const q = `
+		UPDATE users
+		SET deleted_at = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, email, name, created_at;
+	`
+	var u models.User
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, q, userID).Scan(&u.ID, &u.Email, &u.Name, &u.CreatedAt); err != nil {
+			return err
+		}
+		return queueUserEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("restore user", "user", err)
 	}
 	return &u, nil
 }
 
-func (s *Storage) GetDueURLs(ctx context.Context, limit int) ([]models.UserURL, error) {
+func (s *Storage) PurgeDeletedUsers(ctx context.Context, retentionSeconds int, limit int) (int64, error) {
+	const q = `
+		DELETE FROM users
+		WHERE id IN (
+			SELECT id
+			FROM users
+			WHERE deleted_at IS NOT NULL
+				AND deleted_at <= now() - ($1 || ' seconds')::interval
+			ORDER BY deleted_at ASC
+			LIMIT $2
+		);
+	`
+	tag, err := s.pool.Exec(ctx, q, retentionSeconds, limit)
+	if err != nil {
+		return 0, wrapError("purge deleted users", "user", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (s *Storage) AddURL(ctx context.Context, u models.UserURL, emit models.URLEventFunc) (*models.UserURL, error) {
+	const q = `
+		INSERT INTO user_urls AS uu (id, user_id, url, normalized_url, polling_interval_seconds, cron_expr, timezone, next_run_at, correlation_id, priority)
+		SELECT COALESCE(NULLIF($10, '')::uuid, gen_random_uuid()), id, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, NULLIF($8, ''), $9
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + userURLColumns + `;
+	`
+	var created models.UserURL
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := scanUserURL(tx.QueryRow(ctx, q, u.UserID, u.URL, u.NormalizedURL, u.PollingIntervalSeconds, u.CronExpr, u.Timezone, u.NextRunAt, u.CorrelationID, u.Priority, u.ID), &created); err != nil {
+			return err
+		}
+		return queueURLEvent(ctx, tx, emit, created)
+	})
+	if err != nil {
+		return nil, wrapError("add url", "user", err)
+	}
+	return &created, nil
+}
+
+// ClaimDueURLs atomically leases due rows to the caller. Rows locked or leased
+// by another scheduler instance are skipped, so concurrent replicas never pick
+// the same URL; an expired lease makes the row claimable again.
+//
+// It claims up to limit due URLs, highest priority first. Within a
+// priority users are interleaved so that one user with many due URLs cannot
+// fill the batch: each user with due URLs contributes at most its plan's
+// MaxPerBatch (and never more than limit) of them, by priority and
+// next_run_at, and those are taken round-robin with rank scaled down by the
+// plan's Weight. Per-user candidates are read through user_urls_user_due_idx,
+// so a large backlog for one user does not hide other users' URLs. Manual
+// refreshes bypass claiming altogether: TriggerParse publishes them at
+// PriorityMax.
+func (s *Storage) ClaimDueURLs(ctx context.Context, limit int, lease time.Duration, fairness models.FairnessPolicy) ([]models.UserURL, error) {
 	const q = `
-		SELECT id, user_id, url, normalized_url, polling_interval_seconds, created_at
-		FROM user_urls
-		WHERE next_run_at <= now()
-		ORDER BY next_run_at ASC
-		LIMIT $1;
+		WITH due_users AS (
+			SELECT DISTINCT uu.user_id
+			FROM user_urls uu
+			WHERE uu.next_run_at <= now()
+				AND uu.paused_at IS NULL
+				AND (uu.claimed_until IS NULL OR uu.claimed_until < now())
+				AND (uu.deferred_until IS NULL OR uu.deferred_until <= now())
+		), quotas AS (
+			SELECT du.user_id,
+				COALESCE(p.max_per_batch, $3) AS max_per_batch,
+				GREATEST(COALESCE(p.weight, $4), 1) AS weight
+			FROM due_users du
+			JOIN users u ON u.id = du.user_id AND u.deleted_at IS NULL
+			LEFT JOIN unnest($5::text[], $6::int[], $7::int[]) AS p(plan, max_per_batch, weight) ON p.plan = u.plan
+		), ranked AS (
+			SELECT c.id, c.next_run_at, c.priority, c.rn, q.weight
+			FROM quotas q
+			CROSS JOIN LATERAL (
+				SELECT uu.id, uu.next_run_at, uu.priority,
+					ROW_NUMBER() OVER (ORDER BY uu.priority DESC, uu.next_run_at) AS rn
+				FROM user_urls uu
+				WHERE uu.user_id = q.user_id
+					AND uu.next_run_at <= now()
+					AND uu.paused_at IS NULL
+					AND (uu.claimed_until IS NULL OR uu.claimed_until < now())
+					AND (uu.deferred_until IS NULL OR uu.deferred_until <= now())
+				ORDER BY uu.priority DESC, uu.next_run_at
+				LIMIT CASE WHEN q.max_per_batch > 0 THEN LEAST(q.max_per_batch, $1) ELSE $1 END
+			) c
+		), due AS (
+			-- The due and lease conditions are repeated on uu: when a row locked
+			-- here was just claimed or scheduled by another instance, Postgres
+			-- rechecks only the conditions on the locked row before returning it.
+			SELECT uu.id
+			FROM user_urls uu
+			JOIN ranked r ON r.id = uu.id
+			WHERE uu.next_run_at <= now()
+				AND uu.paused_at IS NULL
+				AND (uu.claimed_until IS NULL OR uu.claimed_until < now())
+				AND (uu.deferred_until IS NULL OR uu.deferred_until <= now())
+			ORDER BY r.priority DESC, r.rn::float8 / r.weight ASC, r.next_run_at ASC
+			LIMIT $1
+			FOR UPDATE OF uu SKIP LOCKED
+		)
+		UPDATE user_urls uu
+		SET claimed_until = now() + ($2 || ' milliseconds')::interval,
+			claim_id = gen_random_uuid()
+		FROM due
+		WHERE uu.id = due.id
+		RETURNING ` + userURLColumns + `, uu.claim_id, COALESCE(uu.correlation_id, ''), uu.deferred_until;
 	`
-	rows, err := s.pool.Query(ctx, q, limit)
+	plans := make([]string, 0, len(fairness.Plans))
+	caps := make([]int32, 0, len(fairness.Plans))
+	weights := make([]int32, 0, len(fairness.Plans))
+	for plan, quota := range fairness.Plans {
+		plans = append(plans, plan)
+		caps = append(caps, int32(quota.MaxPerBatch))
+		weights = append(weights, int32(quota.Weight))
+	}
+
+	rows, err := s.pool.Query(ctx, q, limit, lease.Milliseconds(), fairness.Default.MaxPerBatch, fairness.Default.Weight, plans, caps, weights)
 	if err != nil {
-		return nil, fmt.Errorf("get due urls: %w", err)
+		return nil, wrapError("claim due urls", "url", err)
 	}
 	defer rows.Close()
 
-	result := make([]models.UserURL, 0, 64)
+	result := make([]models.UserURL, 0, limit)
 	for rows.Next() {
 		var u models.UserURL
-		if err := rows.Scan(&u.ID, &u.UserID, &u.URL, &u.NormalizedURL, &u.PollingIntervalSeconds, &u.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan due url: %w", err)
+		if err := rows.Scan(append(userURLFields(&u), &u.ClaimID, &u.CorrelationID, &u.DeferredUntil)...); err != nil {
+			return nil, wrapError("scan due url", "url", err)
 		}
 		result = append(result, u)
 	}
 	if rows.Err() != nil {
-		return nil, fmt.Errorf("rows error: %w", rows.Err())
+		return nil, wrapError("claim due urls", "url", rows.Err())
 	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Priority != result[j].Priority {
+			return result[i].Priority > result[j].Priority
+		}
+		return result[i].NextRunAt.Before(result[j].NextRunAt)
+	})
 	return result, nil
 }
 
-func (s *Storage) MarkScheduled(ctx context.Context, urlID string, intervalSeconds int) error {
+// MarkScheduled advances next_run_at and enqueues the events into the outbox
+// for a whole batch in one statement, so an event is stored exactly when its
+// run is scheduled. Runs whose claim was lost to another instance are skipped
+// together with their events; the number of applied runs is returned.
+func (s *Storage) MarkScheduled(ctx context.Context, runs []models.ScheduledRun) (int, error) {
 	const q = `
-		UPDATE user_urls
-		SET next_run_at = now() + ($2 || ' seconds')::interval
-		WHERE id = $1;
+		WITH batch AS (
+			SELECT *
+			FROM unnest($1::uuid[], $2::uuid[], $3::timestamptz[], $4::text[], $5::text[], $6::bytea[], $7::bytea[], $8::text[])
+				AS b(id, claim_id, next_run_at, event_id, topic, message_key, payload, headers)
+		), updated AS (
+			UPDATE user_urls uu
+			SET next_run_at = b.next_run_at,
+				claimed_until = NULL,
+				claim_id = NULL,
+				correlation_id = NULL,
+				deferred_until = NULL
+			FROM batch b
+			WHERE uu.id = b.id AND uu.claim_id = b.claim_id
+			RETURNING uu.id
+		), queued AS (
+			INSERT INTO outbox (event_id, topic, message_key, payload, url_id, headers)
+			SELECT b.event_id, b.topic, b.message_key, b.payload, b.id, NULLIF(b.headers, '')::jsonb
+			FROM batch b
+			JOIN updated u ON u.id = b.id
+			ON CONFLICT (event_id) DO NOTHING
+		)
+		SELECT count(*) FROM updated;
 	`
-	_, err := s.pool.Exec(ctx, q, urlID, intervalSeconds)
-	if err != nil {
-		return fmt.Errorf("mark scheduled: %w", err)
+	if len(runs) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, len(runs))
+	claimIDs := make([]string, len(runs))
+	nextRunAts := make([]time.Time, len(runs))
+	eventIDs := make([]string, len(runs))
+	topics := make([]string, len(runs))
+	keys := make([][]byte, len(runs))
+	payloads := make([][]byte, len(runs))
+	headers := make([]string, len(runs))
+	for i, run := range runs {
+		ids[i] = run.URLID
+		claimIDs[i] = run.ClaimID
+		nextRunAts[i] = run.NextRunAt
+		eventIDs[i] = run.Event.EventID
+		topics[i] = run.Event.Topic
+		keys[i] = run.Event.Key
+		payloads[i] = run.Event.Payload
+		h, err := encodeHeaders(run.Event.Headers)
+		if err != nil {
+			return 0, wrapError("mark scheduled", "url", err)
+		}
+		headers[i] = h
+	}
+
+	var applied int
+	if err := s.pool.QueryRow(ctx, q, ids, claimIDs, nextRunAts, eventIDs, topics, keys, payloads, headers).Scan(&applied); err != nil {
+		return 0, wrapError("mark scheduled", "url", err)
+	}
+	return applied, nil
+}
+
+// DeferURLs releases claimed URLs without running them and hides them from
+// claiming until their Until time. next_run_at keeps the planned slot.
+func (s *Storage) DeferURLs(ctx context.Context, runs []models.DeferredRun) error {
+	const q = `
+		UPDATE user_urls uu
+		SET deferred_until = b.until,
+			claimed_until = NULL,
+			claim_id = NULL
+		FROM unnest($1::uuid[], $2::uuid[], $3::timestamptz[]) AS b(id, claim_id, until)
+		WHERE uu.id = b.id AND uu.claim_id = b.claim_id;
+	`
+	if len(runs) == 0 {
+		return nil
+	}
+	ids := make([]string, len(runs))
+	claimIDs := make([]string, len(runs))
+	until := make([]time.Time, len(runs))
+	for i, run := range runs {
+		ids[i] = run.URLID
+		claimIDs[i] = run.ClaimID
+		until[i] = run.Until
+	}
+	if _, err := s.pool.Exec(ctx, q, ids, claimIDs, until); err != nil {
+		return wrapError("defer urls", "url", err)
 	}
 	return nil
 }
 
-func (s *Storage) ListUserURLs(ctx context.Context, userID string, limit int) ([]models.UserURL, error) {
+// TriggerParse queues the event built for the URL in the outbox. The URL must
+// belong to the user, and the user must not be deleted; an error from build,
+// such as a rejection of the URL's state, is returned as is.
+func (s *Storage) TriggerParse(ctx context.Context, userID string, urlID string, build func(models.UserURL) (models.OutboxMessage, error)) error {
 	const q = `
-		SELECT id, user_id, url, normalized_url, polling_interval_seconds, created_at
-		FROM user_urls
-		WHERE user_id = $1
-		ORDER BY created_at DESC
-		LIMIT $2;
+		SELECT ` + userURLColumns + `
+		FROM user_urls uu
+		JOIN users u ON u.id = uu.user_id
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.deleted_at IS NULL;
 	`
-	rows, err := s.pool.Query(ctx, q, userID, limit)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return wrapError("trigger parse", "url", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var u models.UserURL
+	if err := scanUserURL(tx.QueryRow(ctx, q, userID, urlID), &u); err != nil {
+		return wrapError("trigger parse", "url", err)
+	}
+	event, err := build(u)
 	if err != nil {
-		return nil, fmt.Errorf("list urls: %w", err)
+		return wrapError("trigger parse", "url", err)
+	}
+	if err := insertOutbox(ctx, tx, event); err != nil {
+		return wrapError("trigger parse", "url", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return wrapError("trigger parse", "url", err)
+	}
+	return nil
+}
+
+// urlHostExpr extracts the lower-cased host from normalized_url.
+const urlHostExpr = `substring(uu.normalized_url from '^[^:]+://(?:[^@/]*@)?([^/:?#]+)')`
+
+// ListUserURLs returns one page of a user's URLs ordered by the sort column
+// and then by ID, so the keyset position in query.After is unambiguous.
+func (s *Storage) ListUserURLs(ctx context.Context, query models.URLListQuery) ([]models.UserURL, error) {
+	column, cast := "uu.created_at", "timestamptz"
+	switch query.Sort {
+	case models.URLSortNextRunAt:
+		column = "uu.next_run_at"
+	case models.URLSortURL:
+		column, cast = "uu.url", "text"
+	}
+	dir, cmp := "ASC", ">"
+	if query.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	args := []any{query.UserID}
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+	where := []string{"uu.user_id = $1"}
+	if query.Host != "" {
+		where = append(where, urlHostExpr+" = "+arg(strings.ToLower(query.Host)))
+	}
+	switch query.Status {
+	case models.URLStatusActive:
+		where = append(where, "uu.paused_at IS NULL AND uu.disabled_at IS NULL")
+	case models.URLStatusPaused:
+		// Auto-disabled URLs are paused too, but listed only as disabled.
+		where = append(where, "uu.paused_at IS NOT NULL AND uu.disabled_at IS NULL")
+	case models.URLStatusDisabled:
+		where = append(where, "uu.disabled_at IS NOT NULL")
+	}
+	if query.Search != "" {
+		where = append(where, "uu.url ILIKE "+arg("%"+escapeLike(query.Search)+"%"))
+	}
+	if query.After != nil {
+		where = append(where, fmt.Sprintf("(%s, uu.id) %s (%s::%s, %s::uuid)", column, cmp, arg(query.After.Value), cast, arg(query.After.ID)))
+	}
+
+	q := `
+		SELECT ` + userURLColumns + `
+		FROM user_urls uu
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY ` + column + ` ` + dir + `, uu.id ` + dir + `
+		LIMIT ` + arg(query.Limit) + `;
+	`
+	rows, err := s.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, wrapError("list urls", "url", err)
 	}
 	defer rows.Close()
 
 	result := make([]models.UserURL, 0, 16)
 	for rows.Next() {
 		var u models.UserURL
-		if err := rows.Scan(&u.ID, &u.UserID, &u.URL, &u.NormalizedURL, &u.PollingIntervalSeconds, &u.CreatedAt); err != nil {
-			return nil, fmt.Errorf("scan url: %w", err)
+		if err := scanUserURL(rows, &u); err != nil {
+			return nil, wrapError("scan url", "url", err)
 		}
 		result = append(result, u)
 	}
 	if rows.Err() != nil {
-		return nil, fmt.Errorf("rows error: %w", rows.Err())
+		return nil, wrapError("list urls", "url", rows.Err())
 	}
 
 	return result, nil
 }
+
+// UpdateURL changes the schedule and/or priority of a URL. A positive
+// intervalSeconds replaces any cron schedule; zero keeps the schedule. A nil
+// priority keeps the current one. Like every rewrite of next_run_at it drops a
+// pending host-limit deferral, whose token was reserved for the old slot.
+func (s *Storage) UpdateURL(ctx context.Context, userID string, urlID string, intervalSeconds int, priority *int, emit models.URLEventFunc) (*models.UserURL, error) {
+	const q = `
+		UPDATE user_urls uu
+		SET polling_interval_seconds = CASE WHEN $3::int > 0 THEN $3::int ELSE uu.polling_interval_seconds END,
+			cron_expr = CASE WHEN $3::int > 0 THEN NULL ELSE uu.cron_expr END,
+			timezone = CASE WHEN $3::int > 0 THEN NULL ELSE uu.timezone END,
+			next_run_at = CASE
+				WHEN $3::int > 0 THEN LEAST(uu.next_run_at, now() + ($3::int || ' seconds')::interval)
+				ELSE uu.next_run_at
+			END,
+			deferred_until = CASE WHEN $3::int > 0 THEN NULL ELSE uu.deferred_until END,
+			priority = COALESCE($4::smallint, uu.priority)
+		FROM users u
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.id = uu.user_id AND u.deleted_at IS NULL
+		RETURNING ` + userURLColumns + `;
+	`
+	var u models.UserURL
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := scanUserURL(tx.QueryRow(ctx, q, userID, urlID, intervalSeconds, priority), &u); err != nil {
+			return err
+		}
+		return queueURLEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("update url", "url", err)
+	}
+	return &u, nil
+}
+
+func (s *Storage) PauseURL(ctx context.Context, userID string, urlID string, emit models.URLEventFunc) (*models.UserURL, error) {
+	const q = `
+		UPDATE user_urls uu
+		SET paused_at = COALESCE(uu.paused_at, now())
+		FROM users u
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.id = uu.user_id AND u.deleted_at IS NULL
+		RETURNING ` + userURLColumns + `;
+	`
+	var u models.UserURL
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := scanUserURL(tx.QueryRow(ctx, q, userID, urlID), &u); err != nil {
+			return err
+		}
+		return queueURLEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("pause url", "url", err)
+	}
+	return &u, nil
+}
+
+// ResumeURL shifts next_run_at of an interval URL by the time spent paused, so
+// it keeps the remaining part of its interval instead of firing immediately or
+// losing a run. A paused cron URL resumes at cronNext(url), its next slot.
+// A deferral from before the pause is dropped with the old slot.
+func (s *Storage) ResumeURL(ctx context.Context, userID string, urlID string, cronNext func(models.UserURL) (time.Time, error), emit models.URLEventFunc) (*models.UserURL, error) {
+	const lock = `
+		SELECT ` + userURLColumns + `
+		FROM user_urls uu
+		JOIN users u ON u.id = uu.user_id
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.deleted_at IS NULL
+		FOR UPDATE OF uu;
+	`
+	const q = `
+		UPDATE user_urls uu
+		SET next_run_at = CASE
+				WHEN uu.paused_at IS NULL THEN uu.next_run_at
+				WHEN $3::timestamptz IS NOT NULL THEN $3::timestamptz
+				ELSE GREATEST(now(), uu.next_run_at + (now() - uu.paused_at))
+			END,
+			deferred_until = CASE WHEN uu.paused_at IS NULL THEN uu.deferred_until ELSE NULL END,
+			paused_at = NULL,
+			disabled_at = NULL,
+			disabled_reason = NULL,
+			consecutive_failures = CASE WHEN uu.disabled_at IS NULL THEN uu.consecutive_failures ELSE 0 END,
+			parse_failures = CASE WHEN uu.disabled_at IS NULL THEN uu.parse_failures ELSE 0 END
+		FROM users u
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.id = uu.user_id AND u.deleted_at IS NULL
+		RETURNING ` + userURLColumns + `;
+	`
+	var u models.UserURL
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		if err := scanUserURL(tx.QueryRow(ctx, lock, userID, urlID), &u); err != nil {
+			return err
+		}
+		var next *time.Time
+		if u.Paused && u.CronExpr != "" {
+			t, err := cronNext(u)
+			if err != nil {
+				return err
+			}
+			next = &t
+		}
+		if err := scanUserURL(tx.QueryRow(ctx, q, userID, urlID, next), &u); err != nil {
+			return err
+		}
+		return queueURLEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return nil, wrapError("resume url", "url", err)
+	}
+	return &u, nil
+}
+
+func (s *Storage) DeleteURL(ctx context.Context, userID string, urlID string, emit models.URLEventFunc) error {
+	const q = `
+		DELETE FROM user_urls uu
+		USING users u
+		WHERE uu.id = $2 AND uu.user_id = $1 AND u.id = uu.user_id AND u.deleted_at IS NULL
+		RETURNING ` + userURLColumns + `;
+	`
+	err := s.inTx(ctx, func(tx pgx.Tx) error {
+		var u models.UserURL
+		if err := scanUserURL(tx.QueryRow(ctx, q, userID, urlID), &u); err != nil {
+			return err
+		}
+		return queueURLEvent(ctx, tx, emit, u)
+	})
+	if err != nil {
+		return wrapError("delete url", "url", err)
+	}
+	return nil
+}
+
+// RecordRunResult stores the outcome of a run. Results older than the one
+// already recorded, and redeliveries of the same result event, are ignored.
+// Every failure counts towards consecutive_failures and so the backoff, but
+// only failed parse runs count towards disableAfter: when one brings
+// parse_failures to it the URL is paused and the event built by onDisable is
+// written to the outbox in the same transaction. A publish failure says
+// nothing about the URL itself.
+func (s *Storage) RecordRunResult(ctx context.Context, result models.RunResult, disableAfter int, onDisable func(models.UserURL) (models.OutboxMessage, error)) error {
+	const q = `
+		UPDATE user_urls uu
+		SET last_run_at = $2,
+			last_status = $3,
+			last_error = NULLIF($4, ''),
+			last_result_event_id = $5,
+			consecutive_failures = CASE WHEN $3 = 'succeeded' THEN 0 ELSE uu.consecutive_failures + 1 END,
+			parse_failures = CASE
+				WHEN $3 = 'succeeded' THEN 0
+				WHEN $3 = 'publish_failed' THEN uu.parse_failures
+				ELSE uu.parse_failures + 1
+			END,
+			paused_at = CASE WHEN $3 NOT IN ('succeeded', 'publish_failed') AND $6 > 0 AND uu.paused_at IS NULL AND uu.parse_failures + 1 >= $6
+				THEN now() ELSE uu.paused_at END,
+			disabled_at = CASE WHEN $3 NOT IN ('succeeded', 'publish_failed') AND $6 > 0 AND uu.paused_at IS NULL AND uu.parse_failures + 1 >= $6
+				THEN now() ELSE uu.disabled_at END,
+			disabled_reason = CASE WHEN $3 NOT IN ('succeeded', 'publish_failed') AND $6 > 0 AND uu.paused_at IS NULL AND uu.parse_failures + 1 >= $6
+				THEN 'too many consecutive failures' ELSE uu.disabled_reason END
+		WHERE uu.id = $1
+			AND uu.last_result_event_id IS DISTINCT FROM $5
+			AND (uu.last_run_at IS NULL OR uu.last_run_at <= $2)
+		RETURNING ` + userURLColumns + `, uu.disabled_at IS NOT DISTINCT FROM now();
+	`
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return wrapError("record run result", "url", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var u models.UserURL
+	var disabled bool
+	row := tx.QueryRow(ctx, q, result.URLID, result.FinishedAt, result.Status, result.Error, result.EventID, disableAfter)
+	if err := row.Scan(append(userURLFields(&u), &disabled)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return wrapError("record run result", "url", err)
+	}
+
+	if disabled && onDisable != nil {
+		event, err := onDisable(u)
+		if err != nil {
+			return wrapError("record run result", "url", err)
+		}
+		if err := insertOutbox(ctx, tx, event); err != nil {
+			return wrapError("record run result", "url", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return wrapError("record run result", "url", err)
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds.
+func (s *Storage) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func queueUserEvent(ctx context.Context, tx pgx.Tx, emit models.UserEventFunc, u models.User) error {
+	if emit == nil {
+		return nil
+	}
+	event, err := emit(u)
+	if err != nil {
+		return err
+	}
+	return insertOutbox(ctx, tx, event)
+}
+
+func queueURLEvent(ctx context.Context, tx pgx.Tx, emit models.URLEventFunc, u models.UserURL) error {
+	if emit == nil {
+		return nil
+	}
+	event, err := emit(u)
+	if err != nil {
+		return err
+	}
+	return insertOutbox(ctx, tx, event)
+}
+
+// escapeLike escapes LIKE wildcards so s matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// userURLColumns is the select list matching userURLFields; queries alias
+// user_urls as uu.
+const userURLColumns = `uu.id, uu.user_id, uu.url, uu.normalized_url, uu.polling_interval_seconds,
+		COALESCE(uu.cron_expr, ''), COALESCE(uu.timezone, ''),
+		uu.paused_at IS NOT NULL, uu.created_at, uu.next_run_at, uu.last_run_at,
+		COALESCE(uu.last_status, ''), COALESCE(uu.last_error, ''), uu.consecutive_failures,
+		uu.disabled_at, COALESCE(uu.disabled_reason, ''), uu.priority`
+
+func userURLFields(u *models.UserURL) []any {
+	return []any{
+		&u.ID, &u.UserID, &u.URL, &u.NormalizedURL, &u.PollingIntervalSeconds,
+		&u.CronExpr, &u.Timezone,
+		&u.Paused, &u.CreatedAt, &u.NextRunAt, &u.LastRunAt,
+		&u.LastStatus, &u.LastError, &u.ConsecutiveFailures,
+		&u.DisabledAt, &u.DisabledReason, &u.Priority,
+	}
+}
+
+func scanUserURL(row pgx.Row, u *models.UserURL) error {
+	return row.Scan(userURLFields(u)...)
+}
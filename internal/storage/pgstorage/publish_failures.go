@@ -0,0 +1,112 @@
+package pgstorage
+
+import (
+	"context"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkOutboxDead moves a message that ran out of publish attempts from the
+// outbox to publish_failures, keeping its outbox id for a later replay.
+func (s *Storage) MarkOutboxDead(ctx context.Context, id int64, lastError string) error {
+	const q = `
+		WITH moved AS (
+			DELETE FROM outbox
+			WHERE id = $1
+			RETURNING id, event_id, url_id, topic, message_key, payload, headers, attempts, created_at
+		)
+		INSERT INTO publish_failures (outbox_id, event_id, url_id, topic, message_key, payload, headers, attempts, last_error, created_at)
+		SELECT id, event_id, url_id, topic, message_key, payload, headers, attempts + 1, $2, created_at
+		FROM moved
+		ON CONFLICT (event_id) DO UPDATE
+		SET outbox_id = EXCLUDED.outbox_id,
+			attempts = publish_failures.attempts + EXCLUDED.attempts,
+			last_error = EXCLUDED.last_error,
+			failed_at = now();
+	`
+	if _, err := s.pool.Exec(ctx, q, id, lastError); err != nil {
+		return wrapError("mark outbox dead", "outbox message", err)
+	}
+	return nil
+}
+
+// ListPublishFailures returns up to limit failures with id greater than
+// afterID, in id order.
+func (s *Storage) ListPublishFailures(ctx context.Context, afterID int64, limit int) ([]models.PublishFailure, error) {
+	const q = `
+		SELECT id, event_id, COALESCE(url_id::text, ''), topic, COALESCE(message_key, ''::bytea), payload, headers,
+			attempts, COALESCE(last_error, ''), created_at, failed_at
+		FROM publish_failures
+		WHERE id > $1
+		ORDER BY id ASC
+		LIMIT $2;
+	`
+	rows, err := s.pool.Query(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, wrapError("list publish failures", "publish failure", err)
+	}
+	defer rows.Close()
+
+	result := make([]models.PublishFailure, 0, limit)
+	for rows.Next() {
+		var f models.PublishFailure
+		var key []byte
+		if err := rows.Scan(&f.ID, &f.EventID, &f.URLID, &f.Topic, &key, &f.Payload, &f.Headers,
+			&f.Attempts, &f.LastError, &f.CreatedAt, &f.FailedAt); err != nil {
+			return nil, wrapError("scan publish failure", "publish failure", err)
+		}
+		f.Key = string(key)
+		result = append(result, f)
+	}
+	if rows.Err() != nil {
+		return nil, wrapError("list publish failures", "publish failure", rows.Err())
+	}
+	return result, nil
+}
+
+// ReplayPublishFailure moves the message back to the outbox with a fresh
+// attempt budget; the relay publishes it on its next tick. It gets its old
+// outbox id back, so it still goes out before the messages held behind it.
+func (s *Storage) ReplayPublishFailure(ctx context.Context, id int64) error {
+	const q = `
+		WITH moved AS (
+			DELETE FROM publish_failures
+			WHERE id = $1
+			RETURNING outbox_id, event_id, url_id, topic, message_key, payload, headers
+		), queued AS (
+			INSERT INTO outbox (id, event_id, url_id, topic, message_key, payload, headers)
+			SELECT COALESCE(outbox_id, nextval(pg_get_serial_sequence('outbox', 'id'))), event_id, url_id, topic, message_key, payload, headers
+			FROM moved
+			ON CONFLICT (event_id) DO UPDATE
+			SET sent_at = NULL,
+				attempts = 0,
+				last_error = NULL,
+				next_attempt_at = now()
+		)
+		SELECT count(*) FROM moved;
+	`
+	var moved int
+	if err := s.pool.QueryRow(ctx, q, id).Scan(&moved); err != nil {
+		return wrapError("replay publish failure", "publish failure", err)
+	}
+	if moved == 0 {
+		return wrapError("replay publish failure", "publish failure", pgx.ErrNoRows)
+	}
+	return nil
+}
+
+func (s *Storage) DiscardPublishFailure(ctx context.Context, id int64) error {
+	const q = `
+		DELETE FROM publish_failures
+		WHERE id = $1;
+	`
+	tag, err := s.pool.Exec(ctx, q, id)
+	if err != nil {
+		return wrapError("discard publish failure", "publish failure", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return wrapError("discard publish failure", "publish failure", pgx.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package pgstorage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/LehaAlexey/Users/internal/models"
+	"github.com/LehaAlexey/Users/internal/testdb"
+)
+
+func TestRecordRunResultPublishFailuresDoNotDisable(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	user := testdb.CreateUser(t, pool, "user@example.com")
+	urlID := testdb.AddURL(t, pool, user, "https://shop.example/", time.Now())
+
+	const disableAfter = 3
+	finished := time.Now().Add(-time.Hour)
+	record := func(i int, status string) {
+		t.Helper()
+		result := models.RunResult{URLID: urlID, EventID: fmt.Sprintf("result-%d", i), Status: status, Error: "boom", FinishedAt: finished.Add(time.Duration(i) * time.Second)}
+		if err := s.RecordRunResult(ctx, result, disableAfter, nil); err != nil {
+			t.Fatalf("RecordRunResult: %v", err)
+		}
+	}
+	state := func() (failures int, disabled bool) {
+		t.Helper()
+		err := pool.QueryRow(ctx, `SELECT consecutive_failures, disabled_at IS NOT NULL FROM user_urls WHERE id = $1`, urlID).Scan(&failures, &disabled)
+		if err != nil {
+			t.Fatalf("read url: %v", err)
+		}
+		return failures, disabled
+	}
+
+	// A broker outage: far more failed publishes than the threshold.
+	for i := 0; i < 2*disableAfter; i++ {
+		record(i, models.RunStatusPublishFailed)
+	}
+	if failures, disabled := state(); disabled || failures != 2*disableAfter {
+		t.Fatalf("after publish failures: consecutive_failures=%d disabled=%v, want %d and not disabled", failures, disabled, 2*disableAfter)
+	}
+
+	for i := 0; i < disableAfter; i++ {
+		if _, disabled := state(); disabled {
+			t.Fatalf("disabled after %d failed runs, want %d", i, disableAfter)
+		}
+		record(100+i, models.RunStatusFailed)
+	}
+	if _, disabled := state(); !disabled {
+		t.Fatalf("not disabled after %d failed runs", disableAfter)
+	}
+}
@@ -0,0 +1,58 @@
+package pgstorage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LehaAlexey/Users/internal/services/userservice"
+	"github.com/LehaAlexey/Users/internal/testdb"
+)
+
+func TestGetUserByEmail(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	id := testdb.CreateUser(t, pool, "Alice@Example.com")
+	u, err := s.GetUserByEmail(ctx, "alice@example.COM")
+	if err != nil {
+		t.Fatalf("GetUserByEmail: %v", err)
+	}
+	if u.ID != id {
+		t.Fatalf("got user %s, want %s", u.ID, id)
+	}
+
+	if _, err = s.GetUserByEmail(ctx, "bob@example.com"); !errors.Is(err, userservice.ErrNotFound) {
+		t.Fatalf("missing user: got %v, want not found", err)
+	}
+}
+
+func TestCreateUserRejectsCaseVariantEmail(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	testdb.CreateUser(t, pool, "Alice@Example.com")
+	_, err := s.CreateUser(ctx, "alice@example.com", "Alice", nil)
+	if !errors.Is(err, userservice.ErrAlreadyExists) {
+		t.Fatalf("CreateUser: got %v, want already exists", err)
+	}
+}
+
+func TestDeletedUserReleasesEmail(t *testing.T) {
+	pool := testdb.New(t)
+	s := New(pool)
+	ctx := context.Background()
+
+	old := testdb.CreateUser(t, pool, "carol@example.com")
+	if err := s.DeleteUser(ctx, old, nil); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if _, err := s.CreateUser(ctx, "Carol@example.com", "Carol", nil); err != nil {
+		t.Fatalf("CreateUser with released email: %v", err)
+	}
+	if _, err := s.RestoreUser(ctx, old, nil); !errors.Is(err, userservice.ErrAlreadyExists) {
+		t.Fatalf("RestoreUser: got %v, want already exists", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package testdb gives integration tests a freshly migrated Postgres schema.
+// Tests using it are skipped unless USERS_TEST_DATABASE_URL points at a
+// database the tests may create schemas in.
+package testdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const envDatabaseURL = "USERS_TEST_DATABASE_URL"
+
+// New creates a schema, applies migrations/*.sql to it and returns a pool
+// whose connections use it. The schema is dropped when the test ends.
+func New(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv(envDatabaseURL)
+	if dsn == "" {
+		t.Skip(envDatabaseURL + " is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("testdb: connect: %v", err)
+	}
+	schema := "test_" + randomSuffix(t)
+	// Extensions are database-wide; keep them in public so dropping the test
+	// schema does not take them away from concurrent runs.
+	setup := `
+		CREATE EXTENSION IF NOT EXISTS pgcrypto WITH SCHEMA public;
+		CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+		CREATE SCHEMA ` + schema + `;
+	`
+	if _, err := admin.Exec(ctx, setup); err != nil {
+		admin.Close()
+		t.Fatalf("testdb: create schema: %v", err)
+	}
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("testdb: parse url: %v", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema + ",public"
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("testdb: connect: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Close()
+		_, _ = admin.Exec(context.Background(), `DROP SCHEMA `+schema+` CASCADE`)
+		admin.Close()
+	})
+
+	for _, file := range migrations(t) {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("testdb: read %s: %v", file, err)
+		}
+		if _, err := pool.Exec(ctx, string(sql)); err != nil {
+			t.Fatalf("testdb: apply %s: %v", filepath.Base(file), err)
+		}
+	}
+	return pool
+}
+
+// CreateUser inserts a user and returns its ID.
+func CreateUser(t testing.TB, pool *pgxpool.Pool, email string) string {
+	t.Helper()
+	var id string
+	err := pool.QueryRow(context.Background(), `INSERT INTO users (email, name) VALUES ($1, $1) RETURNING id`, email).Scan(&id)
+	if err != nil {
+		t.Fatalf("testdb: create user: %v", err)
+	}
+	return id
+}
+
+// AddURL inserts a URL due at nextRunAt and returns its ID.
+func AddURL(t testing.TB, pool *pgxpool.Pool, userID string, url string, nextRunAt time.Time) string {
+	t.Helper()
+	const q = `
+		INSERT INTO user_urls (user_id, url, normalized_url, next_run_at)
+		VALUES ($1, $2, $2, $3)
+		RETURNING id
+	`
+	var id string
+	if err := pool.QueryRow(context.Background(), q, userID, url, nextRunAt).Scan(&id); err != nil {
+		t.Fatalf("testdb: add url: %v", err)
+	}
+	return id
+}
+
+func migrations(t testing.TB) []string {
+	_, file, _, _ := runtime.Caller(0)
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(file), "..", "..", "migrations", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("testdb: no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func randomSuffix(t testing.TB) string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("testdb: random: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
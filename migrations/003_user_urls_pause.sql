@@ -0,0 +1,4 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ;
+
+DROP INDEX IF EXISTS user_urls_next_run_idx;
+CREATE INDEX IF NOT EXISTS user_urls_next_run_active_idx ON user_urls (next_run_at) WHERE paused_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS claim_id UUID;
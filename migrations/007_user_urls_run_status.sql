@@ -0,0 +1,5 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS last_run_at TIMESTAMPTZ;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS last_status TEXT;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS last_error TEXT;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS last_result_event_id TEXT;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS consecutive_failures INT NOT NULL DEFAULT 0;
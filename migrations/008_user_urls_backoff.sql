@@ -0,0 +1,4 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMPTZ;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS disabled_reason TEXT;
+
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS url_id UUID;
@@ -0,0 +1,2 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS cron_expr TEXT;
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS timezone TEXT;
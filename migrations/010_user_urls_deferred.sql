@@ -0,0 +1 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS deferred_until TIMESTAMPTZ;
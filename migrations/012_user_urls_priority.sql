@@ -0,0 +1 @@
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0;
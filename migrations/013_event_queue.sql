@@ -0,0 +1,12 @@
+-- Used by the postgres publisher backend instead of Kafka.
+CREATE TABLE IF NOT EXISTS event_queue (
+    id BIGSERIAL PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    message_key BYTEA,
+    payload BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS event_queue_event_id_ux ON event_queue (event_id);
+CREATE INDEX IF NOT EXISTS event_queue_topic_id_idx ON event_queue (topic, id);
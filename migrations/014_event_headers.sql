@@ -0,0 +1,2 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS headers JSONB;
+ALTER TABLE event_queue ADD COLUMN IF NOT EXISTS headers JSONB;
@@ -0,0 +1,17 @@
+-- Outbox messages that exhausted their publish attempts. They stay here until
+-- an operator replays them into the outbox or discards them.
+CREATE TABLE IF NOT EXISTS publish_failures (
+    id BIGSERIAL PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    url_id UUID,
+    topic TEXT NOT NULL,
+    message_key BYTEA,
+    payload BYTEA NOT NULL,
+    headers JSONB,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS publish_failures_event_id_ux ON publish_failures (event_id);
@@ -0,0 +1,2 @@
+-- Keyset pagination of a user's URLs in the default created_at order.
+CREATE INDEX IF NOT EXISTS user_urls_user_created_idx ON user_urls (user_id, created_at, id);
@@ -0,0 +1,6 @@
+-- Admin user listing: keyset order, email prefix and name substring search.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS users_created_id_idx ON users (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS users_email_lower_prefix_idx ON users (lower(email) text_pattern_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS users_name_trgm_idx ON users USING gin (name gin_trgm_ops) WHERE deleted_at IS NULL;
@@ -0,0 +1,20 @@
+-- Emails are unique regardless of case, matching GetUserByEmail. Rows that
+-- differ only in email case must be merged by hand first; the check below
+-- names one of them instead of leaving a bare unique violation.
+DO $$
+DECLARE
+    dup TEXT;
+BEGIN
+    SELECT lower(email) INTO dup
+    FROM users
+    GROUP BY lower(email)
+    HAVING count(*) > 1
+    LIMIT 1;
+    IF FOUND THEN
+        RAISE EXCEPTION 'users_email_ux: several users share email % ignoring case; merge them before applying this migration', dup;
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS users_email_ux;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_ux ON users (lower(email));
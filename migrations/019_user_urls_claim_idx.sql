@@ -0,0 +1,3 @@
+-- ClaimDueURLs reads each due user's URLs in (priority, next_run_at) order
+-- and stops at the user's per-batch quota.
+CREATE INDEX IF NOT EXISTS user_urls_user_due_idx ON user_urls (user_id, priority DESC, next_run_at) WHERE paused_at IS NULL;
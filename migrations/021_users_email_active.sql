@@ -0,0 +1,5 @@
+-- Soft-deleted users no longer hold on to their email, so it can be
+-- registered again while they wait for the purge; RestoreUser then fails
+-- with a conflict.
+DROP INDEX IF EXISTS users_email_ux;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_ux ON users (lower(email)) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+-- ClaimOutbox only claims the oldest unsent message per topic and key.
+CREATE INDEX IF NOT EXISTS outbox_pending_key_idx ON outbox (topic, message_key, id) WHERE sent_at IS NULL;
@@ -0,0 +1,6 @@
+-- A dead-lettered message keeps its outbox id so that a replay goes back to
+-- its place in the key's order, and later messages for the same topic and key
+-- are held until it is replayed or discarded.
+ALTER TABLE publish_failures ADD COLUMN IF NOT EXISTS outbox_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS publish_failures_key_idx ON publish_failures (topic, message_key);
@@ -0,0 +1,4 @@
+-- Consecutive failed parse runs. Unlike consecutive_failures, which also counts
+-- failed publishes and drives backoff, only these count towards auto-disable,
+-- so a broker outage does not disable every URL it touches.
+ALTER TABLE user_urls ADD COLUMN IF NOT EXISTS parse_failures INT NOT NULL DEFAULT 0;